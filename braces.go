@@ -0,0 +1,143 @@
+// braces.go
+package main
+
+type braceKind int
+
+const (
+	braceBlock braceKind = iota
+	braceFunction
+	braceControl
+	braceAggregate
+	braceInitializer
+)
+
+var controlKeywords = map[string]bool{
+	"if": true, "while": true, "for": true, "switch": true,
+}
+
+func checkBracePlacement(analysis *FileAnalysis, filename string, lineNum int) []Violation {
+	var violations []Violation
+	code := analysis.Code
+	var stack []braceKind
+
+	report := func(tok Token, id string) {
+		violations = append(violations, atToken(Violation{
+			Rule:      "C-L7",
+			MessageID: id,
+			Line:      tok.Line,
+			Severity:  SeverityMinor,
+		}, tok))
+	}
+
+	for i, tok := range code {
+		if tok.Kind == TokKeyword && (tok.Text == "else" || tok.Text == "while") && i > 0 {
+			prev := code[i-1]
+			closesDo := tok.Text == "while" && prev.Text == "}" && isDoBlockClose(code, i-1)
+			if prev.Text == "}" && prev.Line != tok.Line && (tok.Text == "else" || closesDo) {
+				report(tok, "misplaced-"+tok.Text)
+			}
+			continue
+		}
+		if tok.Kind != TokPunct {
+			continue
+		}
+
+		switch tok.Text {
+		case "{":
+			kind := classifyBrace(code, i, stack)
+			stack = append(stack, kind)
+			if i == 0 {
+				continue
+			}
+			prev := code[i-1]
+			nextOnSameLine := i+1 < len(code) && code[i+1].Line == tok.Line && code[i+1].Text != "}"
+
+			switch kind {
+			case braceFunction:
+				if prev.Line == tok.Line || nextOnSameLine {
+					report(tok, "misplaced-function-brace")
+				}
+			case braceControl:
+				if prev.Line != tok.Line {
+					report(tok, "misplaced-control-brace")
+				} else if nextOnSameLine {
+					report(tok, "code-after-control-brace")
+				}
+			case braceAggregate, braceInitializer:
+				if prev.Line != tok.Line {
+					report(tok, "misplaced-brace")
+				}
+			}
+		case "}":
+			kind := braceBlock
+			if len(stack) > 0 {
+				kind = stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+			}
+			if kind == braceInitializer || kind == braceAggregate || i == 0 {
+				continue
+			}
+			prev := code[i-1]
+			if prev.Text != "{" && prev.Line == tok.Line {
+				report(tok, "misplaced-closing-brace")
+			} else if kind == braceFunction && i+1 < len(code) && code[i+1].Line == tok.Line {
+				report(tok, "code-after-function-brace")
+			}
+		}
+	}
+	return violations
+}
+
+// classifyBrace works out what the '{' at index i opens from the tokens
+// preceding it and the braces currently open.
+func classifyBrace(code []Token, i int, open []braceKind) braceKind {
+	if len(open) > 0 && open[len(open)-1] == braceInitializer {
+		return braceInitializer
+	}
+	if i == 0 {
+		return braceBlock
+	}
+	prev := code[i-1]
+	switch {
+	case prev.Text == "else" || prev.Text == "do":
+		return braceControl
+	case prev.Text == "=":
+		return braceInitializer
+	case prev.Text == ")":
+		if isCastClose(code, i-1) {
+			return braceInitializer
+		}
+		paren := matchingOpen(code, i-1)
+		if paren > 0 {
+			before := code[paren-1]
+			if before.Kind == TokKeyword && controlKeywords[before.Text] {
+				return braceControl
+			}
+			if before.Kind == TokIdent && len(open) == 0 {
+				return braceFunction
+			}
+		}
+	case isTagKeyword(prev.Text):
+		return braceAggregate
+	case prev.Kind == TokIdent && i >= 2 && isTagKeyword(code[i-2].Text):
+		return braceAggregate
+	}
+	return braceBlock
+}
+
+// isDoBlockClose reports whether the '}' at index i closes a do block.
+func isDoBlockClose(code []Token, i int) bool {
+	depth := 0
+	for k := i; k >= 0; k-- {
+		switch code[k].Text {
+		case "}":
+			depth++
+		case "{":
+			depth--
+			if depth == 0 {
+				return k > 0 && code[k-1].Text == "do"
+			}
+		}
+	}
+	return false
+}
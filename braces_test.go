@@ -0,0 +1,73 @@
+// braces_test.go
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClassifyBrace(t *testing.T) {
+	tests := []struct {
+		source string
+		want   braceKind
+	}{
+		{"{", braceBlock},
+		{"int main(void) {", braceFunction},
+		{"int main(void)\n{", braceFunction},
+		{"if (a) {", braceControl},
+		{"while (a && (b || c)) {", braceControl},
+		{"} else {", braceControl},
+		{"do {", braceControl},
+		{"int t[] = {", braceInitializer},
+		{"p = (point_t){", braceInitializer},
+		{"struct s {", braceAggregate},
+		{"typedef union {", braceAggregate},
+		{"enum color {", braceAggregate},
+	}
+	for _, tt := range tests {
+		code := codeTokens(tokenize(tt.source))
+		last := len(code) - 1
+		if got := classifyBrace(code, last, nil); got != tt.want {
+			t.Errorf("classifyBrace(%q) = %v, want %v", tt.source, got, tt.want)
+		}
+	}
+}
+
+func TestCheckBracePlacement(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		lines  []int // lines of the expected violations
+	}{
+		{"else on the brace line", "void f(void)\n{\n\tif (a) {\n\t\tb();\n\t} else {\n\t\tc();\n\t}\n}\n", nil},
+		{"else on its own line", "void f(void)\n{\n\tif (a) {\n\t\tb();\n\t}\n\telse {\n\t\tc();\n\t}\n}\n", []int{6}},
+		{"do-while on the brace line", "void f(void)\n{\n\tdo {\n\t\tb();\n\t} while (x);\n}\n", nil},
+		{"do-while on its own line", "void f(void)\n{\n\tdo {\n\t\tb();\n\t}\n\twhile (x);\n}\n", []int{6}},
+		{"while after a block", "void f(void)\n{\n\tif (a) {\n\t\tb();\n\t}\n\twhile (x);\n}\n", nil},
+		{"control brace on the next line", "void f(void)\n{\n\tif (a)\n\t{\n\t\tb();\n\t}\n}\n", []int{4}},
+		{"code after a control brace", "void f(void)\n{\n\tif (a) { b();\n\t}\n}\n", []int{3}},
+		{"function brace on the signature line", "int main(void) {\n\treturn 0;\n}\n", []int{1}},
+		{"code after a function brace", "int main(void)\n{ return 0;\n}\n", []int{2}},
+		{"initializer", "int t[] = {1, 2};\nint u[2][2] = {{1, 2}, {3, 4}};\n", nil},
+		{"initializer on the next line", "int t[] =\n{1, 2};\n", []int{2}},
+		{"structure", "struct s {\n\tint a;\n};\n", nil},
+		{"structure brace on the next line", "struct s\n{\n\tint a;\n};\n", []int{2}},
+		{"closing brace after code", "void f(void)\n{\n\tif (a) {\n\t\tb(); }\n}\n", []int{4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens := tokenize(tt.source)
+			analysis := &FileAnalysis{Tokens: tokens, Code: codeTokens(tokens)}
+			var lines []int
+			for _, v := range checkBracePlacement(analysis, "test.c", 0) {
+				if v.Rule != "C-L7" {
+					t.Errorf("violation of rule %s, want C-L7", v.Rule)
+				}
+				lines = append(lines, v.Line)
+			}
+			if !reflect.DeepEqual(lines, tt.lines) {
+				t.Errorf("violations on lines %v, want %v", lines, tt.lines)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+// commands.go
+package main
+
+import (
+	"embed"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Long descriptions of the rules, with an incorrect and a correct example
+// separated by "--- bad" and "--- good" lines.
+//
+//go:embed rules/*.txt
+var ruleDocs embed.FS
+
+// Highest verification level, at which every rule is registered
+const maxLevel = 2
+
+type RuleDoc struct {
+	Text string
+	Bad  string
+	Good string
+}
+
+// ruleDoc returns the embedded documentation of a rule.
+func ruleDoc(code string) (RuleDoc, bool) {
+	content, err := ruleDocs.ReadFile("rules/" + code + ".txt")
+	if err != nil {
+		return RuleDoc{}, false
+	}
+	text, examples, _ := strings.Cut(string(content), "\n--- bad\n")
+	bad, good, _ := strings.Cut(examples, "--- good\n")
+	return RuleDoc{
+		Text: strings.TrimSpace(text),
+		Bad:  strings.TrimRight(bad, "\n"),
+		Good: strings.TrimRight(good, "\n"),
+	}, true
+}
+
+// runCommand runs the subcommand named by the first argument, if any, and
+// returns its exit code. A subcommand always wins over a path of the same
+// name, which is analyzed when written differently, as in "./rules".
+func runCommand(args []string) (int, bool) {
+	if len(args) == 0 {
+		return 0, false
+	}
+	switch args[0] {
+	case "rules", "list-rules":
+		warnShadowedPath(args[0])
+		return runRulesCommand(args[1:]), true
+	case "explain":
+		warnShadowedPath(args[0])
+		return runExplainCommand(args[1:]), true
+	}
+	return 0, false
+}
+
+// warnShadowedPath tells how to analyze a file or directory named like the
+// subcommand being run.
+func warnShadowedPath(name string) {
+	if note := shadowedPathNote(name); note != "" {
+		fmt.Fprintln(os.Stderr, note)
+	}
+}
+
+func shadowedPathNote(name string) string {
+	if _, err := os.Stat(name); err != nil {
+		return ""
+	}
+	return fmt.Sprintf("Note: running the %s subcommand; analyze ./%s to check the path of that name", name, name)
+}
+
+// commandOptions holds the flags shared by the subcommands, which use the
+// configuration and level of an analysis and print like its report.
+type commandOptions struct {
+	config string
+	level  int
+	lang   string
+	color  string
+}
+
+func commandFlags(name string) (*flag.FlagSet, *commandOptions) {
+	options := &commandOptions{}
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.StringVar(&options.config, "config", "", "Path to JSON configuration file (default: "+DefaultConfigFile+" if present)")
+	flags.IntVar(&options.level, "level", 1, "Verification level (1=basic, 2=advanced)")
+	flags.StringVar(&options.lang, "lang", "", "Language of the messages: "+languageNames()+" (default: from LANG)")
+	flags.StringVar(&options.color, "color", "auto", "Colored output: auto, always or never")
+	return flags, options
+}
+
+// parseCommandFlags parses the arguments of a subcommand and sets up the
+// language and colors of its output.
+func parseCommandFlags(flags *flag.FlagSet, args []string, options *commandOptions) bool {
+	if err := flags.Parse(args); err != nil {
+		return false
+	}
+	err := SetLanguage(options.lang)
+	if err == nil {
+		err = SetColor(options.color, os.Stdout)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return false
+	}
+	return true
+}
+
+// runRulesCommand lists every rule with its state under the configuration.
+func runRulesCommand(args []string) int {
+	flags, options := commandFlags("rules")
+	if !parseCommandFlags(flags, args, options) {
+		return ExitUsage
+	}
+	config, err := loadConfigFile(options.config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitUsage
+	}
+
+	analyzer := NewAnalyzer(maxLevel, config)
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, tr("rules.header"))
+	for _, rule := range analyzer.sortedRules() {
+		severity := rule.Severity
+		if configured, ok := config.Severity(rule.Code); ok {
+			severity = configured
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", rule.Code, rule.Level, severity,
+			ruleState(rule, config, options.level), rule.Name)
+	}
+	w.Flush()
+	return ExitOK
+}
+
+// ruleState describes whether a rule runs at the given level.
+func ruleState(rule Rule, config *Config, level int) string {
+	enabled := config.IsEnabled(rule.Code, !rule.Optional)
+	switch {
+	case !enabled && rule.Optional:
+		return tr("rules.optional")
+	case !enabled:
+		return tr("rules.disabled")
+	case rule.Level > level:
+		return tr("rules.level", rule.Level)
+	}
+	return tr("rules.enabled")
+}
+
+// runExplainCommand prints the documentation of a rule.
+func runExplainCommand(args []string) int {
+	flags, options := commandFlags("explain")
+	if !parseCommandFlags(flags, args, options) {
+		return ExitUsage
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "Usage: %s explain <rule_code>\n", os.Args[0])
+		return ExitUsage
+	}
+	config, err := loadConfigFile(options.config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitUsage
+	}
+
+	code := strings.ToUpper(flags.Arg(0))
+	rule, ok := NewAnalyzer(maxLevel, config).rules[code]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: unknown rule '%s', see '%s rules'\n", flags.Arg(0), os.Args[0])
+		return ExitUsage
+	}
+	severity := rule.Severity
+	if configured, ok := config.Severity(rule.Code); ok {
+		severity = configured
+	}
+
+	fmt.Printf("%s%s - %s%s\n", ColorBold, rule.Code, rule.Name, ColorReset)
+	fmt.Println(tr("explain.summary", rule.Description, severity, rule.Level))
+	doc, ok := ruleDoc(rule.Code)
+	if !ok {
+		return ExitOK
+	}
+	fmt.Printf("\n%s\n", doc.Text)
+	fmt.Printf("\n%s%s%s\n%s\n", ColorRed, tr("explain.bad"), ColorReset, indentLines(doc.Bad, "    "))
+	fmt.Printf("\n%s%s%s\n%s\n", ColorGreen, tr("explain.good"), ColorReset, indentLines(doc.Good, "    "))
+	return ExitOK
+}
+
+// sortedRules returns the rules ordered by category, then by number.
+func (a *Analyzer) sortedRules() []Rule {
+	rules := make([]Rule, 0, len(a.rules))
+	for _, rule := range a.rules {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		ci, ni := splitRuleCode(rules[i].Code)
+		cj, nj := splitRuleCode(rules[j].Code)
+		if ci != cj {
+			return ci < cj
+		}
+		return ni < nj
+	})
+	return rules
+}
+
+// splitRuleCode splits "C-F10" into its category "C-F" and number 10.
+func splitRuleCode(code string) (string, int) {
+	end := len(code)
+	for end > 0 && code[end-1] >= '0' && code[end-1] <= '9' {
+		end--
+	}
+	number, _ := strconv.Atoi(code[end:])
+	return code[:end], number
+}
+
+func indentLines(text, prefix string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
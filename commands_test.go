@@ -0,0 +1,68 @@
+// commands_test.go
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRunCommandShadowedPath(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(dir+"/rules", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	tests := []struct {
+		args []string
+		ok   bool
+	}{
+		{nil, false},
+		{[]string{"./rules"}, false},
+		{[]string{"main.c", "rules"}, false},
+		{[]string{"rules"}, true},
+		{[]string{"list-rules", "-level", "2"}, true},
+		{[]string{"explain", "C-F3"}, true},
+	}
+	for _, tt := range tests {
+		var code int
+		var ok bool
+		out := captureStdout(t, func() { code, ok = runCommand(tt.args) })
+		if ok != tt.ok || ok && code != ExitOK {
+			t.Errorf("runCommand(%q) = %d, %v, want a subcommand run: %v", tt.args, code, ok, tt.ok)
+		}
+		if ok && out == "" {
+			t.Errorf("runCommand(%q) printed nothing", tt.args)
+		}
+	}
+
+	// The rules directory exists here, the explain one does not
+	if note := shadowedPathNote("rules"); !strings.Contains(note, "./rules") {
+		t.Errorf("shadowedPathNote(rules) = %q, want a hint at ./rules", note)
+	}
+	if note := shadowedPathNote("explain"); note != "" {
+		t.Errorf("shadowedPathNote(explain) = %q, want none", note)
+	}
+}
+
+func TestRuleDocs(t *testing.T) {
+	analyzer := NewAnalyzer(maxLevel, DefaultConfig())
+	for code := range analyzer.rules {
+		doc, ok := ruleDoc(code)
+		if !ok {
+			t.Errorf("%s: no documentation", code)
+			continue
+		}
+		if doc.Text == "" || doc.Bad == "" || doc.Good == "" {
+			t.Errorf("%s: documentation without text or examples", code)
+		}
+	}
+}
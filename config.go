@@ -0,0 +1,199 @@
+// config.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const (
+	DefaultConfigFile = ".epicstyle.json"
+	DefaultTabWidth   = 8
+)
+
+// Config holds the user settings read from a JSON file such as:
+//
+//	{
+//	  "tab_width": 8,
+//	  "scoring": "epitech",
+//	  "rules": {
+//	    "C-V6": { "pattern": "^[a-z][a-z0-9_]*$" },
+//	    "C-G4": { "weight": 0.2, "severity": "info" },
+//	    "C-L6": { "enabled": false }
+//	  }
+//	}
+type Config struct {
+	TabWidth int                   `json:"tab_width,omitempty"`
+	Scoring  string                `json:"scoring,omitempty"`
+	Rules    map[string]RuleConfig `json:"rules"`
+
+	patterns map[string]*regexp.Regexp
+}
+
+type RuleConfig struct {
+	Enabled   *bool     `json:"enabled,omitempty"`
+	Pattern   string    `json:"pattern,omitempty"`
+	Allowed   []string  `json:"allowed,omitempty"`
+	Forbidden []string  `json:"forbidden,omitempty"`
+	Threshold int       `json:"threshold,omitempty"`
+	Weight    *float64  `json:"weight,omitempty"`
+	Severity  *Severity `json:"severity,omitempty"`
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		Rules:    make(map[string]RuleConfig),
+		patterns: make(map[string]*regexp.Regexp),
+	}
+}
+
+func LoadConfig(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config := DefaultConfig()
+	if err := json.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if config.Rules == nil {
+		config.Rules = make(map[string]RuleConfig)
+	}
+	if _, err := scoringModel(config.Scoring); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	for code, rule := range config.Rules {
+		if rule.Pattern == "" {
+			continue
+		}
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%s: rule %s: invalid pattern: %v", path, code, err)
+		}
+		config.patterns[code] = re
+	}
+	return config, nil
+}
+
+// loadConfigFile loads the given configuration file, or the default one
+// from the current directory when no path is given and it exists.
+func loadConfigFile(path string) (*Config, error) {
+	if path == "" {
+		if _, err := os.Stat(DefaultConfigFile); err != nil {
+			return DefaultConfig(), nil
+		}
+		path = DefaultConfigFile
+	}
+	return LoadConfig(path)
+}
+
+// IsEnabled reports whether a rule is enabled, falling back to byDefault
+// when the configuration does not say.
+func (c *Config) IsEnabled(code string, byDefault bool) bool {
+	if c == nil {
+		return byDefault
+	}
+	rule, ok := c.Rules[code]
+	if !ok || rule.Enabled == nil {
+		return byDefault
+	}
+	return *rule.Enabled
+}
+
+// Pattern returns the custom naming pattern of a rule, or nil when the
+// rule uses its built-in check.
+func (c *Config) Pattern(code string) *regexp.Regexp {
+	if c == nil {
+		return nil
+	}
+	return c.patterns[code]
+}
+
+// Allowed returns the values a rule accepts, or fallback when the
+// configuration does not list any.
+func (c *Config) Allowed(code string, fallback []string) []string {
+	if c == nil || c.Rules[code].Allowed == nil {
+		return fallback
+	}
+	return c.Rules[code].Allowed
+}
+
+// Forbidden returns the values a rule rejects.
+func (c *Config) Forbidden(code string) []string {
+	if c == nil {
+		return nil
+	}
+	return c.Rules[code].Forbidden
+}
+
+// SetAllowed replaces the values accepted by a rule.
+func (c *Config) SetAllowed(code string, values []string) {
+	rule := c.Rules[code]
+	rule.Allowed = values
+	c.Rules[code] = rule
+}
+
+// readNameList reads a file listing one name per line. Blank lines and
+// lines starting with '#' are ignored.
+func readNameList(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		names = append(names, strings.Fields(line)...)
+	}
+	return names, nil
+}
+
+// Threshold returns the limit configured for a rule, or fallback.
+func (c *Config) Threshold(code string, fallback int) int {
+	if c == nil || c.Rules[code].Threshold <= 0 {
+		return fallback
+	}
+	return c.Rules[code].Threshold
+}
+
+// Weight returns the penalty configured for the violations of a rule,
+// which then replaces the one of their severity.
+func (c *Config) Weight(code string) (float64, bool) {
+	if c == nil || c.Rules[code].Weight == nil {
+		return 0, false
+	}
+	return *c.Rules[code].Weight, true
+}
+
+// Severity returns the severity configured for the violations of a rule.
+func (c *Config) Severity(code string) (Severity, bool) {
+	if c == nil || c.Rules[code].Severity == nil {
+		return 0, false
+	}
+	return *c.Rules[code].Severity, true
+}
+
+// Enable turns a rule on, optional rules included.
+func (c *Config) Enable(code string) {
+	rule := c.Rules[code]
+	enabled := true
+	rule.Enabled = &enabled
+	c.Rules[code] = rule
+}
+
+// GetTabWidth returns the number of columns of a tab, 8 by default as
+// with the official checker.
+func (c *Config) GetTabWidth() int {
+	if c == nil || c.TabWidth <= 0 {
+		return DefaultTabWidth
+	}
+	return c.TabWidth
+}
@@ -0,0 +1,105 @@
+// delivery.go
+package main
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Files that must never be delivered, matched on their base name
+var forbiddenArtifacts = []struct {
+	pattern string
+	kind    term
+}{
+	{"*.o", "object-file"},
+	{"*.a", "static-library"},
+	{"*.so", "shared-library"},
+	{"*.gcda", "coverage-data"},
+	{"*.gcno", "coverage-notes"},
+	{"vgcore.*", "core-dump"},
+	{"*~", "editor-backup"},
+	{"#*#", "editor-autosave"},
+}
+
+// Magic numbers of executable formats
+var binarySignatures = [][]byte{
+	[]byte("\x7fELF"),
+	{0xcf, 0xfa, 0xed, 0xfe},
+	{0xfe, 0xed, 0xfa, 0xcf},
+	[]byte("MZ"),
+}
+
+// checkDelivery walks a project tree, honoring .gitignore files, and
+// returns a result for every file that should not be delivered.
+func checkDelivery(root string) ([]FileResult, error) {
+	var results []FileResult
+	matcher := &ignoreMatcher{}
+
+	err := filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel := relSlash(root, p)
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			if rel != "" && matcher.Match(rel, true) {
+				return filepath.SkipDir
+			}
+			return matcher.AddFile(filepath.Join(p, ".gitignore"), rel)
+		}
+		if matcher.Match(rel, false) {
+			return nil
+		}
+
+		kind := artifactKind(p, info)
+		if kind == "" {
+			return nil
+		}
+		violations := []Violation{{
+			Rule:      "C-O4",
+			MessageID: "forbidden-delivery-file",
+			Args:      []any{rel, kind},
+			Line:      0,
+			Severity:  SeverityMajor,
+		}}
+		results = append(results, FileResult{
+			Filename:   p,
+			Violations: violations,
+		})
+		return nil
+	})
+	return results, err
+}
+
+func artifactKind(filename string, info os.FileInfo) term {
+	base := filepath.Base(filename)
+	for _, artifact := range forbiddenArtifacts {
+		if ok, _ := path.Match(artifact.pattern, base); ok {
+			return artifact.kind
+		}
+	}
+	if strings.HasSuffix(base, ".c") || strings.HasSuffix(base, ".h") || info.Size() < 4 {
+		return ""
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+	header := make([]byte, 4)
+	if _, err := file.Read(header); err != nil {
+		return ""
+	}
+	for _, signature := range binarySignatures {
+		if bytes.HasPrefix(header, signature) {
+			return "compiled-binary"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,59 @@
+// delivery_test.go
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCheckDelivery(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"main.c":             "int main(void)\n{\n\treturn 0;\n}\n",
+		"Makefile":           "all:\n",
+		"main.o":             "",
+		"lib/libmy.a":        "",
+		"lib/my.h~":          "",
+		"a.out":              "\x7fELF\x02\x01\x01",
+		"notes.txt":          "Remember the tests\n",
+		"build/tmp.o":        "",
+		"src/#main.c#":       "",
+		"src/vgcore.1234":    "",
+		"src/keep.gcno":      "",
+		"src/.gitignore":     "*.gcno\n",
+		".gitignore":         "/build/\n",
+		".git/objects/x.o":   "",
+		"tests/unit_tests.c": "",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results, err := checkDelivery(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, result := range results {
+		for _, v := range result.Violations {
+			if v.Rule != "C-O4" || v.Line != 0 {
+				t.Errorf("%s: got %s at line %d, want C-O4 at line 0", result.Filename, v.Rule, v.Line)
+			}
+		}
+		got = append(got, relSlash(root, result.Filename))
+	}
+	// .gitignore hides build/ and the coverage notes of src/, .git is
+	// never walked
+	want := []string{"a.out", "lib/libmy.a", "lib/my.h~", "main.o", "src/#main.c#", "src/vgcore.1234"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,134 @@
+// duplicates.go
+package main
+
+import "hash/fnv"
+
+const defaultDuplicateTokens = 50
+
+// dupSequence is the normalized token stream of one function body.
+type dupSequence struct {
+	analysis *FileAnalysis
+	fn       FunctionDecl
+	tokens   []Token
+	ids      []int
+}
+
+type dupLocation struct {
+	seq int
+	pos int
+}
+
+// checkDuplicateCode looks for token sequences repeated across function
+// bodies of different files. Identifiers and literals are normalized so
+// that renaming the variables of a copied helper does not hide it. Both
+// copies are reported.
+func checkDuplicateCode(analyses []*FileAnalysis, config *Config) map[string][]Violation {
+	violations := make(map[string][]Violation)
+	minTokens := config.Threshold("C-O3", defaultDuplicateTokens)
+	seqs := buildSequences(analyses)
+	index := make(map[uint64][]dupLocation)
+
+	report := func(at, other *dupSequence, atPos, otherPos, length int) {
+		line := at.tokens[atPos].Line
+		otherLine := other.tokens[otherPos].Line
+		violations[at.analysis.Filename] = append(violations[at.analysis.Filename], atTokens(Violation{
+			Rule:      "C-O3",
+			MessageID: "duplicated-code",
+			Args:      []any{length, at.fn.Name.Text, other.analysis.Filename, otherLine, other.fn.Name.Text},
+			Line:      line,
+			Severity:  SeverityMajor,
+		}, at.tokens[atPos], at.tokens[atPos+length-1]))
+	}
+
+	for s := range seqs {
+		seq := &seqs[s]
+		for i := 0; i+minTokens <= len(seq.ids); {
+			hash := hashWindow(seq.ids[i : i+minTokens])
+			best, bestLen := dupLocation{}, 0
+			for _, loc := range index[hash] {
+				if seqs[loc.seq].analysis == seq.analysis {
+					continue
+				}
+				n := commonPrefix(seqs[loc.seq].ids[loc.pos:], seq.ids[i:])
+				if n >= minTokens && n > bestLen {
+					best, bestLen = loc, n
+				}
+			}
+			if bestLen == 0 {
+				index[hash] = append(index[hash], dupLocation{s, i})
+				i++
+				continue
+			}
+
+			other := &seqs[best.seq]
+			report(seq, other, i, best.pos, bestLen)
+			report(other, seq, best.pos, i, bestLen)
+			for j := i; j < i+bestLen && j+minTokens <= len(seq.ids); j++ {
+				h := hashWindow(seq.ids[j : j+minTokens])
+				index[h] = append(index[h], dupLocation{s, j})
+			}
+			i += bestLen
+		}
+	}
+	return violations
+}
+
+func buildSequences(analyses []*FileAnalysis) []dupSequence {
+	var seqs []dupSequence
+	ids := make(map[string]int)
+	for _, analysis := range analyses {
+		for _, fn := range analysis.FunctionDecls {
+			if fn.Prototype || fn.Nested || fn.BodyClose >= len(analysis.Code) {
+				continue
+			}
+			seq := dupSequence{
+				analysis: analysis,
+				fn:       fn,
+				tokens:   analysis.Code[fn.BodyOpen+1 : fn.BodyClose],
+			}
+			for _, tok := range seq.tokens {
+				key := normalizeToken(tok)
+				id, ok := ids[key]
+				if !ok {
+					id = len(ids)
+					ids[key] = id
+				}
+				seq.ids = append(seq.ids, id)
+			}
+			seqs = append(seqs, seq)
+		}
+	}
+	return seqs
+}
+
+func normalizeToken(tok Token) string {
+	switch tok.Kind {
+	case TokIdent:
+		return "$id"
+	case TokNumber:
+		return "$num"
+	case TokString:
+		return "$str"
+	case TokChar:
+		return "$chr"
+	}
+	return tok.Text
+}
+
+func hashWindow(ids []int) uint64 {
+	h := fnv.New64a()
+	buf := make([]byte, 4)
+	for _, id := range ids {
+		buf[0], buf[1], buf[2], buf[3] = byte(id), byte(id>>8), byte(id>>16), byte(id>>24)
+		h.Write(buf)
+	}
+	return h.Sum64()
+}
+
+func commonPrefix(a, b []int) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,58 @@
+// duplicates_test.go
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCheckDuplicateCode(t *testing.T) {
+	body := "{\n\tint i = 0;\n\n\twhile (i < 10) {\n\t\tif (i % 2 == 0)\n\t\t\tcount += i;\n\t\ti++;\n\t}\n\treturn count;\n}\n"
+	renamed := strings.ReplaceAll(body, "count", "total")
+	type file struct {
+		name   string
+		source string
+	}
+	tests := []struct {
+		name  string
+		files []file
+		want  map[string][]int // lines reported per file
+	}{
+		{"same file", []file{
+			{"a.c", "int first(int count)\n" + body + "\nint second(int total)\n" + renamed},
+		}, map[string][]int{}},
+		{"different files", []file{
+			{"a.c", "int first(int count)\n" + body},
+			{"b.c", "\nint second(int total)\n" + renamed},
+		}, map[string][]int{"a.c": {3}, "b.c": {4}}},
+		{"too short", []file{
+			{"a.c", "int first(int a)\n{\n\treturn a + 1;\n}\n"},
+			{"b.c", "int second(int b)\n{\n\treturn b + 1;\n}\n"},
+		}, map[string][]int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.Rules["C-O3"] = RuleConfig{Threshold: 20}
+			analyzer := NewAnalyzer(2, config)
+			var analyses []*FileAnalysis
+			for _, f := range tt.files {
+				analyses = append(analyses, analyzer.newFileAnalysis(f.name, []byte(f.source)))
+			}
+
+			got := make(map[string][]int)
+			for filename, violations := range checkDuplicateCode(analyses, config) {
+				for _, v := range violations {
+					if v.Rule != "C-O3" {
+						t.Errorf("%s:%d: rule %s, want C-O3", filename, v.Line, v.Rule)
+					}
+					got[filename] = append(got[filename], v.Line)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
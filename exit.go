@@ -0,0 +1,71 @@
+// exit.go
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// Exit codes of the program
+const (
+	ExitOK         = 0
+	ExitViolations = 1 // the report fails the FailPolicy
+	ExitUsage      = 2 // invalid options or configuration
+	ExitIOError    = 3 // a file or directory could not be read, written or analyzed
+	ExitInternal   = 4 // a rule failed on a file, a bug of epicstyle
+)
+
+// FailPolicy decides whether a report fails the run. By default any minor
+// or major violation does, info ones never do.
+type FailPolicy struct {
+	FailOn        Severity
+	MaxViolations int      // violations at or above FailOn tolerated
+	MinScore      *float64 // lowest accepted global score, if any
+}
+
+// Failed reports whether the report breaks the policy.
+func (p FailPolicy) Failed(report *Report) bool {
+	count := 0
+	for _, file := range report.Files {
+		for _, v := range file.Violations {
+			if v.Severity >= p.FailOn {
+				count++
+			}
+		}
+	}
+	if count > p.MaxViolations {
+		return true
+	}
+	return p.MinScore != nil && report.TotalScore < *p.MinScore
+}
+
+// exitCode returns the exit code of an analysis: rules failing take
+// precedence over files that could not be analyzed, which take precedence
+// over violations.
+func exitCode(report *Report, policy FailPolicy) int {
+	for _, fileErr := range report.Errors {
+		if fileErr.Internal {
+			return ExitInternal
+		}
+	}
+	if len(report.Errors) > 0 {
+		return ExitIOError
+	}
+	if policy.Failed(report) {
+		return ExitViolations
+	}
+	return ExitOK
+}
+
+// exitOnError prints err and exits with ExitIOError when it comes from the
+// file system, with the given code otherwise.
+func exitOnError(err error, code int) {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		code = ExitIOError
+	}
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	os.Exit(code)
+}
@@ -0,0 +1,78 @@
+// exit_test.go
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	report := func(score float64, errors []FileError, severities ...Severity) *Report {
+		r := &Report{TotalScore: score, Errors: errors}
+		file := FileResult{Filename: "main.c"}
+		for _, severity := range severities {
+			file.Violations = append(file.Violations, Violation{Rule: "C-L1", Severity: severity})
+		}
+		r.Files = append(r.Files, file)
+		return r
+	}
+	minScore := func(score float64) *float64 { return &score }
+	unreadable := FileError{Filename: "logo.c", Error: "binary file"}
+	internal := FileError{Filename: "main.c", Error: "internal error in rule C-F3: index out of range", Internal: true}
+
+	tests := []struct {
+		name   string
+		report *Report
+		policy FailPolicy
+		want   int
+	}{
+		{"clean", report(100, nil), FailPolicy{FailOn: SeverityMinor}, ExitOK},
+		{"info only", report(100, nil, SeverityInfo, SeverityInfo), FailPolicy{FailOn: SeverityMinor}, ExitOK},
+		{"minor", report(98, nil, SeverityMinor), FailPolicy{FailOn: SeverityMinor}, ExitViolations},
+		{"minor when failing on major", report(98, nil, SeverityMinor), FailPolicy{FailOn: SeverityMajor}, ExitOK},
+		{"major when failing on major", report(95, nil, SeverityMajor), FailPolicy{FailOn: SeverityMajor}, ExitViolations},
+		{"info when failing on info", report(100, nil, SeverityInfo), FailPolicy{FailOn: SeverityInfo}, ExitViolations},
+		{"within tolerance", report(96, nil, SeverityMinor, SeverityMinor), FailPolicy{FailOn: SeverityMinor, MaxViolations: 2}, ExitOK},
+		{"over tolerance", report(94, nil, SeverityMinor, SeverityMinor, SeverityMajor), FailPolicy{FailOn: SeverityMinor, MaxViolations: 2}, ExitViolations},
+		{"tolerance counts failing severities only", report(96, nil, SeverityMinor, SeverityInfo, SeverityInfo), FailPolicy{FailOn: SeverityMinor, MaxViolations: 1}, ExitOK},
+		{"score above minimum", report(90, nil, SeverityMinor), FailPolicy{FailOn: SeverityMinor, MaxViolations: 5, MinScore: minScore(80)}, ExitOK},
+		{"score at minimum", report(80, nil, SeverityMinor), FailPolicy{FailOn: SeverityMinor, MaxViolations: 5, MinScore: minScore(80)}, ExitOK},
+		{"score below minimum", report(79.9, nil, SeverityMinor), FailPolicy{FailOn: SeverityMinor, MaxViolations: 5, MinScore: minScore(80)}, ExitViolations},
+		{"score below minimum without violations", report(50, nil), FailPolicy{FailOn: SeverityMinor, MinScore: minScore(80)}, ExitViolations},
+		{"errors", report(100, []FileError{unreadable}), FailPolicy{FailOn: SeverityMinor}, ExitIOError},
+		{"errors before violations", report(90, []FileError{unreadable, unreadable}, SeverityMajor), FailPolicy{FailOn: SeverityMinor}, ExitIOError},
+		{"internal error", report(100, []FileError{internal}), FailPolicy{FailOn: SeverityMinor}, ExitInternal},
+		{"internal error before other errors", report(90, []FileError{unreadable, internal}, SeverityMajor), FailPolicy{FailOn: SeverityMinor}, ExitInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.report, tt.policy); got != tt.want {
+				t.Errorf("exitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzePathUnreadableFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "good.c"), []byte("int foo(void);\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// A dangling link is walked as a file but cannot be read
+	if err := os.Symlink(filepath.Join(dir, "missing.c"), filepath.Join(dir, "bad.c")); err != nil {
+		t.Skip(err)
+	}
+
+	report := NewAnalyzer(1, DefaultConfig()).AnalyzePaths([]string{dir})
+	if len(report.Files) != 1 || report.Files[0].Filename != filepath.Join(dir, "good.c") {
+		t.Errorf("got files %+v, want good.c only", report.Files)
+	}
+	if len(report.Errors) != 1 || report.Errors[0].Filename != filepath.Join(dir, "bad.c") ||
+		report.Errors[0].Error != "open: no such file or directory" {
+		t.Errorf("got errors %+v, want bad.c unreadable", report.Errors)
+	}
+	if code := exitCode(report, FailPolicy{FailOn: SeverityMinor}); code != ExitIOError {
+		t.Errorf("exitCode() = %d, want %d", code, ExitIOError)
+	}
+}
@@ -0,0 +1,52 @@
+// fix.go
+package main
+
+import (
+	"os"
+	"sort"
+)
+
+// Fix replaces Length bytes at Offset in the source with Replacement.
+type Fix struct {
+	Offset      int    `json:"offset"`
+	Length      int    `json:"length"`
+	Replacement string `json:"replacement"`
+}
+
+// applyFixes returns the source with every non-overlapping fix applied.
+// When two fixes overlap, the first one in source order wins.
+func applyFixes(source string, violations []Violation) string {
+	var fixes []Fix
+	for _, v := range violations {
+		if v.Fix != nil {
+			fixes = append(fixes, *v.Fix)
+		}
+	}
+	if len(fixes) == 0 {
+		return source
+	}
+	sort.Slice(fixes, func(i, j int) bool {
+		return fixes[i].Offset < fixes[j].Offset
+	})
+
+	result := make([]byte, 0, len(source))
+	pos := 0
+	for _, fix := range fixes {
+		if fix.Offset < pos || fix.Offset+fix.Length > len(source) {
+			continue
+		}
+		result = append(result, source[pos:fix.Offset]...)
+		result = append(result, fix.Replacement...)
+		pos = fix.Offset + fix.Length
+	}
+	result = append(result, source[pos:]...)
+	return string(result)
+}
+
+func writeFixedFile(filename, content string) error {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, []byte(content), info.Mode().Perm())
+}
@@ -0,0 +1,110 @@
+// fix_test.go
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestApplyFixes(t *testing.T) {
+	fix := func(offset, length int, replacement string) Violation {
+		return Violation{Fix: &Fix{Offset: offset, Length: length, Replacement: replacement}}
+	}
+	tests := []struct {
+		name       string
+		source     string
+		violations []Violation
+		want       string
+	}{
+		{"no fix", "abc", []Violation{{Rule: "C-L1"}}, "abc"},
+		{"insertion", "int f()", []Violation{fix(6, 0, "void")}, "int f(void)"},
+		{"replacement", "char* s", []Violation{fix(4, 2, " *")}, "char *s"},
+		{"deletion", "a  b", []Violation{fix(1, 1, "")}, "a b"},
+		{"unordered", "abcd", []Violation{fix(3, 1, "D"), fix(0, 1, "A")}, "AbcD"},
+		{"overlap keeps the first", "abcd", []Violation{fix(1, 2, "X"), fix(0, 2, "Y")}, "Ycd"},
+		{"adjacent", "abcd", []Violation{fix(0, 2, "X"), fix(2, 2, "Y")}, "XY"},
+		{"at end", "ab", []Violation{fix(2, 0, ";")}, "ab;"},
+		{"past end", "ab", []Violation{fix(1, 5, "X")}, "ab"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := applyFixes(tt.source, tt.violations); got != tt.want {
+				t.Errorf("applyFixes(%q) = %q, want %q", tt.source, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestApplyFixesRoundTrip checks that the fixed source no longer has any
+// fixable violation, and that fixing it again leaves it unchanged.
+func TestApplyFixesRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{"empty parameter list", "int foo()\n{\n\treturn 0;\n}\n", "int foo(void)\n{\n\treturn 0;\n}\n"},
+		{"pointer star", "void foo(char* str, int *  n);\n", "void foo(char *str, int *n);\n"},
+		{"both", "static int foo();\nchar* bar()\n{\n\treturn 0;\n}\n", "static int foo(void);\nchar *bar(void)\n{\n\treturn 0;\n}\n"},
+		{"function returning a pointer", "char* dup(char const *s);\n", "char *dup(char const *s);\n"},
+		{"nothing to fix", "int foo(void);\n", "int foo(void);\n"},
+	}
+	analyzer := NewAnalyzer(2, DefaultConfig())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis := analyzer.newFileAnalysis("test.c", []byte(tt.source))
+			violations, err := analyzer.runRules(analysis)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fixed := applyFixes(tt.source, violations)
+			if fixed != tt.want {
+				t.Fatalf("fixed source = %q, want %q", fixed, tt.want)
+			}
+			analysis = analyzer.newFileAnalysis("test.c", []byte(fixed))
+			if violations, err = analyzer.runRules(analysis); err != nil {
+				t.Fatal(err)
+			}
+			for _, v := range violations {
+				if v.Fix != nil {
+					t.Errorf("fixed source still has a fixable %s violation: %s", v.Rule, v.Message)
+				}
+			}
+			if again := applyFixes(fixed, violations); again != fixed {
+				t.Errorf("fixing again gives %q, want %q", again, fixed)
+			}
+		})
+	}
+}
+
+// TestFixCleanFile checks that -fix leaves a file without fixable
+// violations byte for byte as it was.
+func TestFixCleanFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "clean.c")
+	source := "/*\n** EPITECH PROJECT, 2024\n** clean\n** File description:\n** clean\n*/\n\n" +
+		"int my_strlen(char const *str)\n{\n\tint i = 0;\n\n\twhile (str[i] != '\\0')\n\t\ti++;\n\treturn i;\n}\n"
+	if err := os.WriteFile(filename, []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.Stat(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	analyzer := NewAnalyzer(2, DefaultConfig())
+	analyzer.SetFix(true)
+	if _, err := analyzer.analyzeFile(analyzer.newFileAnalysis(filename, []byte(source))); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != source {
+		t.Errorf("-fix rewrote a clean file:\n%s", content)
+	}
+	if after, err := os.Stat(filename); err != nil || !after.ModTime().Equal(before.ModTime()) {
+		t.Errorf("-fix wrote a clean file again")
+	}
+}
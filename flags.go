@@ -0,0 +1,16 @@
+// flags.go
+package main
+
+import "strings"
+
+// stringList is a flag that may be repeated, collecting every value.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
@@ -0,0 +1,124 @@
+// forbidden.go
+package main
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// nameFilter decides whether a name is permitted from the allowed and
+// forbidden lists of a rule. An empty filter permits everything.
+type nameFilter struct {
+	allowed   map[string]bool
+	forbidden map[string]bool
+}
+
+func newNameFilter(config *Config, code string) nameFilter {
+	filter := nameFilter{}
+	if allowed := config.Allowed(code, nil); allowed != nil {
+		filter.allowed = toSet(allowed)
+	}
+	if forbidden := config.Forbidden(code); len(forbidden) > 0 {
+		filter.forbidden = toSet(forbidden)
+	}
+	return filter
+}
+
+func (f nameFilter) active() bool {
+	return f.allowed != nil || f.forbidden != nil
+}
+
+func (f nameFilter) permits(name string) bool {
+	if f.forbidden[name] {
+		return false
+	}
+	return f.allowed == nil || f.allowed[name]
+}
+
+// checkForbiddenFunctions reports calls to functions that are forbidden,
+// or missing from the allowed list, by the project configuration. The
+// functions and macros defined in the analyzed sources are always allowed.
+func checkForbiddenFunctions(analysis *FileAnalysis, filename string, lineNum int) []Violation {
+	var violations []Violation
+	filter := newNameFilter(analysis.Config, "C-G5")
+	if !filter.active() {
+		return violations
+	}
+
+	variables := make(map[string]bool)
+	for _, decl := range analysis.Declarations {
+		variables[decl.Name.Text] = true
+	}
+	declared := make(map[int]bool)
+	for _, fn := range analysis.FunctionDecls {
+		declared[fn.Name.Offset] = true
+	}
+
+	code := analysis.Code
+	for k := 0; k+1 < len(code); k++ {
+		tok := code[k]
+		if tok.Kind != TokIdent || code[k+1].Text != "(" || declared[tok.Offset] {
+			continue
+		}
+		if k > 0 && (code[k-1].Text == "." || code[k-1].Text == "->") {
+			continue
+		}
+		name := tok.Text
+		if variables[name] || analysis.Project.Defines(name) || filter.permits(name) {
+			continue
+		}
+		violations = append(violations, atToken(Violation{
+			Rule:      "C-G5",
+			MessageID: "forbidden-function",
+			Args:      []any{name},
+			Line:      tok.Line,
+			Severity:  SeverityMajor,
+		}, tok))
+	}
+	return violations
+}
+
+// checkForbiddenHeaders reports #include directives of headers forbidden,
+// or missing from the allowed list, by the project configuration. Quoted
+// includes of the project's own headers are not concerned by the allowed
+// list.
+func checkForbiddenHeaders(analysis *FileAnalysis, filename string, lineNum int) []Violation {
+	var violations []Violation
+	filter := newNameFilter(analysis.Config, "C-G6")
+	if !filter.active() {
+		return violations
+	}
+
+	for _, tok := range analysis.Tokens {
+		if tok.Kind != TokPreproc {
+			continue
+		}
+		directive, ok := parseDirective(tok.Text)
+		if !ok || directive.Name != "include" {
+			continue
+		}
+		header := strings.Trim(directive.Args, "<>\" \t")
+		system := strings.HasPrefix(directive.Args, "<")
+		if filter.forbidden[header] || filter.forbidden[filepath.Base(header)] {
+			// Forbidden, whatever the form of the include
+		} else if !system || filter.permits(header) {
+			continue
+		}
+		violations = append(violations, atToken(Violation{
+			Rule:      "C-G6",
+			MessageID: "forbidden-header",
+			Args:      []any{header},
+			Line:      tok.Line,
+			Severity:  SeverityMajor,
+		}, tok))
+	}
+	return violations
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
@@ -0,0 +1,98 @@
+// forbidden_test.go
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// forbiddenProject analyzes a header and a source together, the source
+// being the file the rules are then run on.
+func forbiddenProject(config *Config, header, source string) *FileAnalysis {
+	analyzer := NewAnalyzer(2, config)
+	analyses := []*FileAnalysis{
+		analyzer.newFileAnalysis("my.h", []byte(header)),
+		analyzer.newFileAnalysis("main.c", []byte(source)),
+	}
+	project := NewProject(analyses)
+	for _, analysis := range analyses {
+		analysis.Project = project
+	}
+	return analyses[1]
+}
+
+func TestCheckForbiddenFunctions(t *testing.T) {
+	header := "#define MIN(a, b) ((a) < (b) ? (a) : (b))\n" +
+		"int my_strlen(char const *str);\n" +
+		"void *malloc(unsigned long size);\n"
+	source := "#include \"my.h\"\n" +
+		"static int helper(int a)\n" +
+		"{\n" +
+		"\treturn MIN(a, 0);\n" +
+		"}\n" +
+		"int main(void)\n" +
+		"{\n" +
+		"\twrite(1, \"a\", 1);\n" +
+		"\tprintf(\"%d\", helper(my_strlen(\"a\")));\n" +
+		"\treturn malloc(4) != 0;\n" +
+		"}\n"
+	config := DefaultConfig()
+	config.Rules["C-G5"] = RuleConfig{Allowed: []string{"write"}}
+	analysis := forbiddenProject(config, header, source)
+
+	type report struct {
+		Line     int
+		Function any
+	}
+	var got []report
+	for _, v := range checkForbiddenFunctions(analysis, "main.c", 0) {
+		if v.Rule != "C-G5" {
+			t.Errorf("line %d: rule %s, want C-G5", v.Line, v.Rule)
+		}
+		if v.MessageID != "forbidden-function" || len(v.Args) != 1 {
+			t.Errorf("line %d: message %s %v, want forbidden-function with the name", v.Line, v.MessageID, v.Args)
+			continue
+		}
+		got = append(got, report{v.Line, v.Args[0]})
+	}
+	// A prototype in a header does not make a function part of the
+	// project: my_strlen and malloc are only declared
+	want := []report{
+		{9, "printf"},
+		{9, "my_strlen"},
+		{10, "malloc"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Without any list, the rule stays quiet
+	if v := checkForbiddenFunctions(forbiddenProject(DefaultConfig(), header, source), "main.c", 0); len(v) != 0 {
+		t.Errorf("no list configured: got %v", v)
+	}
+}
+
+func TestCheckForbiddenHeaders(t *testing.T) {
+	source := "#include <stdio.h>\n" +
+		"#include <stdlib.h>\n" +
+		"#include \"my.h\"\n" +
+		"#include \"include/secret.h\"\n" +
+		"#include <sys/mman.h>\n"
+	config := DefaultConfig()
+	config.Rules["C-G6"] = RuleConfig{
+		Allowed:   []string{"stdlib.h"},
+		Forbidden: []string{"secret.h"},
+	}
+	analysis := forbiddenProject(config, "", source)
+
+	var lines []int
+	for _, v := range checkForbiddenHeaders(analysis, "main.c", 0) {
+		if v.Rule != "C-G6" {
+			t.Errorf("line %d: rule %s, want C-G6", v.Line, v.Rule)
+		}
+		lines = append(lines, v.Line)
+	}
+	if want := []int{1, 4, 5}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("got lines %v, want %v", lines, want)
+	}
+}
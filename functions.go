@@ -0,0 +1,130 @@
+// functions.go
+package main
+
+import "strings"
+
+func checkEmptyParameterList(analysis *FileAnalysis, filename string, lineNum int) []Violation {
+	var violations []Violation
+	code := analysis.Code
+	for _, fn := range analysis.FunctionDecls {
+		if fn.ParamClose != fn.ParamOpen+1 || fn.ParamClose >= len(code) {
+			continue
+		}
+		start := code[fn.ParamOpen].End()
+		violations = append(violations, atTokens(Violation{
+			Rule:      "C-F5",
+			MessageID: "empty-parameter-list",
+			Args:      []any{fn.Name.Text},
+			Line:      fn.Name.Line,
+			Severity:  SeverityMajor,
+			Fix:       &Fix{Offset: start, Length: code[fn.ParamClose].Offset - start, Replacement: "void"},
+		}, code[fn.ParamOpen], code[fn.ParamClose]))
+	}
+	return violations
+}
+
+func checkStructureParameters(analysis *FileAnalysis, filename string, lineNum int) []Violation {
+	var violations []Violation
+	for _, decl := range analysis.Declarations {
+		if decl.Kind != DeclParam || decl.Pointer || !analysis.Project.IsStructType(decl.TypeName) {
+			continue
+		}
+		violations = append(violations, atToken(Violation{
+			Rule:      "C-F6",
+			MessageID: "structure-by-value",
+			Args:      []any{decl.Name.Text, decl.TypeName},
+			Line:      decl.Name.Line,
+			Severity:  SeverityMajor,
+		}, decl.Name))
+	}
+	return violations
+}
+
+// checkCommentsInFunctions flags comments placed between the opening and
+// closing braces of a function. The header comment above the function is
+// outside of its body and therefore allowed.
+func checkCommentsInFunctions(analysis *FileAnalysis, filename string, lineNum int) []Violation {
+	var violations []Violation
+	code := analysis.Code
+	for _, fn := range analysis.FunctionDecls {
+		if fn.Prototype || fn.Nested || fn.BodyClose >= len(code) {
+			continue
+		}
+		open := code[fn.BodyOpen].Offset
+		closing := code[fn.BodyClose].Offset
+		for _, tok := range analysis.Tokens {
+			if tok.Kind != TokComment || tok.Offset <= open || tok.Offset >= closing {
+				continue
+			}
+			violations = append(violations, atToken(Violation{
+				Rule:      "C-F7",
+				MessageID: "comment-in-function",
+				Args:      []any{fn.Name.Text},
+				Line:      tok.Line,
+				Severity:  SeverityMinor,
+			}, tok))
+		}
+	}
+	return violations
+}
+
+func checkNestedFunctions(analysis *FileAnalysis, filename string, lineNum int) []Violation {
+	var violations []Violation
+	for _, fn := range analysis.FunctionDecls {
+		if !fn.Nested || fn.Prototype {
+			continue
+		}
+		violations = append(violations, atToken(Violation{
+			Rule:      "C-F8",
+			MessageID: "nested-function",
+			Args:      []any{fn.Name.Text},
+			Line:      fn.Name.Line,
+			Severity:  SeverityMajor,
+		}, fn.Name))
+	}
+	return violations
+}
+
+// checkUnusedStaticFunctions flags static functions that are never
+// referenced in their translation unit, macros included.
+func checkUnusedStaticFunctions(analysis *FileAnalysis, filename string, lineNum int) []Violation {
+	var violations []Violation
+	declared := make(map[int]bool)
+	for _, fn := range analysis.FunctionDecls {
+		declared[fn.Name.Offset] = true
+	}
+
+	for _, fn := range analysis.FunctionDecls {
+		if !fn.Static || fn.Prototype || fn.Nested || isReferenced(analysis, fn.Name.Text, declared) {
+			continue
+		}
+		violations = append(violations, atToken(Violation{
+			Rule:      "C-F9",
+			MessageID: "unused-static-function",
+			Args:      []any{fn.Name.Text},
+			Line:      fn.Name.Line,
+			Severity:  SeverityMinor,
+		}, fn.Name))
+	}
+	return violations
+}
+
+// isReferenced tells whether name appears in the file other than at the
+// offsets where functions are declared.
+func isReferenced(analysis *FileAnalysis, name string, declared map[int]bool) bool {
+	for _, tok := range analysis.Tokens {
+		switch tok.Kind {
+		case TokIdent:
+			if tok.Text == name && !declared[tok.Offset] {
+				return true
+			}
+		case TokPreproc:
+			for _, macroTok := range tokenize(strings.TrimPrefix(tok.Text, "#")) {
+				if macroTok.Kind == TokIdent && macroTok.Text == name {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
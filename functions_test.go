@@ -0,0 +1,85 @@
+// functions_test.go
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// checkProject runs a rule on the first of the given sources, the others
+// being analyzed along with it as the rest of the project.
+func checkProject(check func(*FileAnalysis, string, int) []Violation, sources ...string) []Violation {
+	analyzer := NewAnalyzer(2, DefaultConfig())
+	var analyses []*FileAnalysis
+	for i, source := range sources {
+		analyses = append(analyses, analyzer.newFileAnalysis(fmt.Sprintf("file%d.c", i), []byte(source)))
+	}
+	project := NewProject(analyses)
+	for _, analysis := range analyses {
+		analysis.Project = project
+	}
+	return check(analyses[0], analyses[0].Filename, 0)
+}
+
+func TestCheckEmptyParameterList(t *testing.T) {
+	tests := []struct {
+		source string
+		lines  []int
+		fixes  []string // source once each fix is applied alone
+	}{
+		{"int foo()\n{\n\treturn 0;\n}\n", []int{1}, []string{"int foo(void)\n{\n\treturn 0;\n}\n"}},
+		{"int foo(void);\nint bar();\n", []int{2}, []string{"int foo(void);\nint bar(void);\n"}},
+		{"int foo( )\n{\n}\n", []int{1}, []string{"int foo(void)\n{\n}\n"}},
+		{"void (*handler)();\n", nil, nil},
+		{"int foo(void)\n{\n\treturn bar();\n}\n", nil, nil},
+		// Truncated sources must not make the rule index past the tokens
+		{"int foo(", nil, nil},
+		{"int foo(\n", nil, nil},
+		{"int foo(int a", nil, nil},
+	}
+	for _, tt := range tests {
+		var lines []int
+		var fixes []string
+		for _, v := range checkProject(checkEmptyParameterList, tt.source) {
+			if v.Rule != "C-F5" || v.Fix == nil {
+				t.Errorf("%q: got %+v, want a fixable C-F5 violation", tt.source, v)
+				continue
+			}
+			lines = append(lines, v.Line)
+			fixes = append(fixes, applyFixes(tt.source, []Violation{v}))
+		}
+		if !reflect.DeepEqual(lines, tt.lines) || !reflect.DeepEqual(fixes, tt.fixes) {
+			t.Errorf("%q: got lines %v and fixes %q, want %v and %q", tt.source, lines, fixes, tt.lines, tt.fixes)
+		}
+	}
+}
+
+func TestCheckStructureParameters(t *testing.T) {
+	header := "typedef struct point_s {\n\tint x;\n} point_t;\ntypedef struct point_s *point_ptr_t;\n"
+	tests := []struct {
+		name   string
+		source string
+		lines  []int
+	}{
+		{"typedef by value", "void draw(point_t p);\n", []int{1}},
+		{"tag by value", "void draw(int color,\n\tstruct point_s p);\n", []int{2}},
+		{"by pointer", "void draw(point_t *p, struct point_s const *q);\n", nil},
+		{"pointer typedef", "void draw(point_ptr_t p);\n", nil},
+		{"unknown structure", "void draw(struct other_s o);\n", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lines []int
+			for _, v := range checkProject(checkStructureParameters, tt.source, header) {
+				if v.Rule != "C-F6" {
+					t.Errorf("violation of rule %s, want C-F6", v.Rule)
+				}
+				lines = append(lines, v.Line)
+			}
+			if !reflect.DeepEqual(lines, tt.lines) {
+				t.Errorf("violations on lines %v, want %v", lines, tt.lines)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// gitignore.go
+package main
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+type ignoreRule struct {
+	base     string // directory of the ignore file, relative to the root
+	pattern  string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// ignoreMatcher implements the subset of .gitignore semantics used by
+// project trees: globs, '**', negation, anchoring and directory patterns.
+type ignoreMatcher struct {
+	rules []ignoreRule
+}
+
+// AddFile loads an ignore file whose patterns apply below base, a slash
+// separated path relative to the walked root ("" for the root itself).
+// A missing file is not an error.
+func (m *ignoreMatcher) AddFile(filename, base string) error {
+	content, err := os.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		m.AddPattern(line, base)
+	}
+	return nil
+}
+
+func (m *ignoreMatcher) AddPattern(line, base string) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return
+	}
+	rule := ignoreRule{base: base}
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	}
+	line = strings.TrimPrefix(line, "\\")
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimSuffix(line, "/")
+	}
+	if strings.Contains(line, "/") {
+		rule.anchored = true
+		line = strings.TrimPrefix(line, "/")
+	}
+	rule.pattern = line
+	m.rules = append(m.rules, rule)
+}
+
+// Match reports whether the slash separated path, relative to the root,
+// is ignored. The last matching pattern wins.
+func (m *ignoreMatcher) Match(rel string, isDir bool) bool {
+	ignored := false
+	for _, rule := range m.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		target := rel
+		if rule.base != "" {
+			if !strings.HasPrefix(rel, rule.base+"/") {
+				continue
+			}
+			target = strings.TrimPrefix(rel, rule.base+"/")
+		}
+		if !rule.anchored {
+			target = path.Base(target)
+		}
+		if matchGlob(rule.pattern, target) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// matchGlob matches a slash separated path against a pattern where '**'
+// stands for any number of directories.
+func matchGlob(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for skip := 0; skip <= len(name); skip++ {
+				if matchSegments(pattern[1:], name[skip:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], name[0]); err != nil || !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// relSlash returns target relative to root with forward slashes.
+func relSlash(root, target string) string {
+	rel, err := filepath.Rel(root, target)
+	if err != nil || rel == "." {
+		return ""
+	}
+	return filepath.ToSlash(rel)
+}
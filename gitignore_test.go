@@ -0,0 +1,105 @@
+// gitignore_test.go
+package main
+
+import "testing"
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"*.o", "main.o", true},
+		{"*.o", "main.c", false},
+		{"*.o", "src/main.o", false},
+		{"src/*.c", "src/main.c", true},
+		{"src/*.c", "src/lib/main.c", false},
+		{"**/*.c", "main.c", true},
+		{"**/*.c", "src/lib/main.c", true},
+		{"src/**", "src/lib/main.c", true},
+		{"src/**", "src", true},
+		{"src/**/test", "src/test", true},
+		{"src/**/test", "src/a/b/test", true},
+		{"src/**/test", "lib/a/test", false},
+		{"main.?", "main.h", true},
+		{"[ab].c", "b.c", true},
+		{"[ab].c", "c.c", false},
+		{"[", "[", false},
+		{"", "", true},
+		{"a", "", false},
+	}
+	for _, tt := range tests {
+		if got := matchGlob(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("matchGlob(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIgnoreMatcher(t *testing.T) {
+	type match struct {
+		rel   string
+		isDir bool
+		want  bool
+	}
+	tests := []struct {
+		name     string
+		patterns []string
+		base     string
+		matches  []match
+	}{
+		{"comments and blank lines", []string{"# *.c", "", "   "}, "", []match{
+			{"main.c", false, false},
+		}},
+		{"unanchored pattern", []string{"*.o"}, "", []match{
+			{"main.o", false, true},
+			{"src/lib/main.o", false, true},
+			{"main.c", false, false},
+		}},
+		{"anchored pattern", []string{"/main.o", "src/*.o"}, "", []match{
+			{"main.o", false, true},
+			{"lib/main.o", false, false},
+			{"src/a.o", false, true},
+			{"lib/src/a.o", false, false},
+		}},
+		{"directory pattern", []string{"build/"}, "", []match{
+			{"build", true, true},
+			{"src/build", true, true},
+			{"build", false, false},
+		}},
+		{"negation", []string{"*.c", "!main.c"}, "", []match{
+			{"util.c", false, true},
+			{"main.c", false, false},
+		}},
+		{"last pattern wins", []string{"!main.c", "*.c"}, "", []match{
+			{"main.c", false, true},
+		}},
+		{"escaped characters", []string{"\\#notes", "\\!todo"}, "", []match{
+			{"#notes", false, true},
+			{"!todo", false, true},
+		}},
+		{"trailing whitespace", []string{"*.o  \r"}, "", []match{
+			{"main.o", false, true},
+		}},
+		{"nested ignore file", []string{"*.o", "/gen"}, "src", []match{
+			{"src/main.o", false, true},
+			{"src/lib/main.o", false, true},
+			{"src/gen", true, true},
+			{"main.o", false, false},
+			{"gen", true, false},
+			{"srcs/main.o", false, false},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher := &ignoreMatcher{}
+			for _, pattern := range tt.patterns {
+				matcher.AddPattern(pattern, tt.base)
+			}
+			for _, m := range tt.matches {
+				if got := matcher.Match(m.rel, m.isDir); got != m.want {
+					t.Errorf("Match(%q, %v) = %v, want %v", m.rel, m.isDir, got, m.want)
+				}
+			}
+		})
+	}
+}
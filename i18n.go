@@ -0,0 +1,106 @@
+// i18n.go
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Catalogue maps the keys of the messages to their text in one language.
+// Violation keys are the stable message IDs found in the JSON report, the
+// key suffixed with ".description" holding the format of the description.
+type Catalogue map[string]string
+
+// DefaultLanguage is used when the environment names no known language,
+// French, the language of the report before the catalogues
+const DefaultLanguage = "fr"
+
+var catalogues = map[string]Catalogue{
+	"en": catalogueEN,
+	"fr": catalogueFR,
+}
+
+// Catalogue of the selected language
+var catalogue = catalogues[DefaultLanguage]
+
+// term is a violation argument naming a concept, translated with the
+// "term." keys of the catalogue while the JSON report keeps its code.
+type term string
+
+// SetLanguage selects the language of the messages. An empty name selects
+// the language of the environment.
+func SetLanguage(lang string) error {
+	if lang == "" {
+		lang = languageFromEnv()
+	}
+	selected, ok := catalogues[lang]
+	if !ok {
+		return fmt.Errorf("unknown language '%s' (available: %s)", lang, languageNames())
+	}
+	catalogue = selected
+	return nil
+}
+
+// languageFromEnv returns the language of the LC_ALL, LC_MESSAGES or LANG
+// locale, in the order of precedence of POSIX.
+func languageFromEnv() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		locale := os.Getenv(name)
+		if locale == "" {
+			continue
+		}
+		lang, _, _ := strings.Cut(locale, "_")
+		lang, _, _ = strings.Cut(lang, ".")
+		if _, ok := catalogues[lang]; ok {
+			return lang
+		}
+		return DefaultLanguage
+	}
+	return DefaultLanguage
+}
+
+func languageNames() string {
+	names := make([]string, 0, len(catalogues))
+	for name := range catalogues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// tr returns the message of a key in the selected language, formatted with
+// args. Keys missing from the catalogue fall back to English.
+func tr(key string, args ...any) string {
+	text, ok := catalogue[key]
+	if !ok {
+		text, ok = catalogueEN[key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// localize renders the message and description of violations from their
+// message ID and arguments.
+func localize(violations []Violation) {
+	for i, v := range violations {
+		if v.MessageID == "" {
+			continue
+		}
+		args := make([]any, len(v.Args))
+		for j, arg := range v.Args {
+			if t, ok := arg.(term); ok {
+				arg = tr("term." + string(t))
+			}
+			args[j] = arg
+		}
+		violations[i].Message = tr(v.MessageID)
+		violations[i].Description = tr(v.MessageID+".description", args...)
+	}
+}
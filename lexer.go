@@ -0,0 +1,224 @@
+// lexer.go
+package main
+
+import "strings"
+
+type TokenKind int
+
+const (
+	TokIdent TokenKind = iota
+	TokKeyword
+	TokNumber
+	TokString
+	TokChar
+	TokPunct
+	TokComment
+	TokPreproc
+)
+
+type Token struct {
+	Kind   TokenKind
+	Text   string
+	Line   int
+	Col    int // byte column, turned into a display column by atDisplayColumns
+	Offset int
+}
+
+// End returns the byte offset just past the token.
+func (t Token) End() int {
+	return t.Offset + len(t.Text)
+}
+
+// EndPosition returns the line and column just past the token, which
+// differ from its start line for block comments and continued directives.
+func (t Token) EndPosition() (int, int) {
+	last := strings.LastIndexByte(t.Text, '\n')
+	if last < 0 {
+		return t.Line, t.Col + len(t.Text)
+	}
+	return t.Line + strings.Count(t.Text, "\n"), len(t.Text) - last
+}
+
+var cKeywords = map[string]bool{
+	"auto": true, "break": true, "case": true, "char": true, "const": true,
+	"continue": true, "default": true, "do": true, "double": true, "else": true,
+	"enum": true, "extern": true, "float": true, "for": true, "goto": true,
+	"if": true, "inline": true, "int": true, "long": true, "register": true,
+	"restrict": true, "return": true, "short": true, "signed": true, "sizeof": true,
+	"static": true, "struct": true, "switch": true, "typedef": true, "union": true,
+	"unsigned": true, "void": true, "volatile": true, "while": true, "_Bool": true,
+}
+
+// Longest punctuators first so that greedy matching works
+var cPunctuators = []string{
+	">>=", "<<=", "...",
+	"->", "++", "--", "<<", ">>", "<=", ">=", "==", "!=", "&&", "||",
+	"+=", "-=", "*=", "/=", "%=", "&=", "^=", "|=", "##",
+}
+
+// tokenize splits C source into tokens. Whitespace is dropped, comments and
+// preprocessor directives are kept as single tokens so rules can skip them.
+func tokenize(src string) []Token {
+	var tokens []Token
+	line, col := 1, 1
+	lineStart := true
+	i := 0
+
+	advance := func(n int) {
+		for k := 0; k < n && i < len(src); k++ {
+			if src[i] == '\n' {
+				line++
+				col = 1
+			} else {
+				col++
+			}
+			i++
+		}
+	}
+	emit := func(kind TokenKind, n int) {
+		tokens = append(tokens, Token{Kind: kind, Text: src[i : i+n], Line: line, Col: col, Offset: i})
+		advance(n)
+		lineStart = false
+	}
+
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == '\n':
+			advance(1)
+			lineStart = true
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f' || c == '\v':
+			advance(1)
+		case c == '#' && lineStart:
+			emit(TokPreproc, scanPreproc(src, i))
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				emit(TokComment, len(src)-i)
+			} else {
+				emit(TokComment, end+4)
+			}
+		case strings.HasPrefix(src[i:], "//"):
+			emit(TokComment, scanToEOL(src, i))
+		case c == '"' || c == '\'':
+			kind := TokString
+			if c == '\'' {
+				kind = TokChar
+			}
+			emit(kind, scanQuoted(src, i))
+		case isDigit(c) || (c == '.' && i+1 < len(src) && isDigit(src[i+1])):
+			emit(TokNumber, scanNumber(src, i))
+		case isIdentStart(c):
+			n := 1
+			for i+n < len(src) && isIdentChar(src[i+n]) {
+				n++
+			}
+			kind := TokIdent
+			if cKeywords[src[i:i+n]] {
+				kind = TokKeyword
+			}
+			emit(kind, n)
+		default:
+			n := 1
+			for _, p := range cPunctuators {
+				if strings.HasPrefix(src[i:], p) {
+					n = len(p)
+					break
+				}
+			}
+			emit(TokPunct, n)
+		}
+	}
+	return tokens
+}
+
+// codeTokens returns the tokens that are neither comments nor directives.
+func codeTokens(tokens []Token) []Token {
+	var code []Token
+	for _, t := range tokens {
+		if t.Kind != TokComment && t.Kind != TokPreproc {
+			code = append(code, t)
+		}
+	}
+	return code
+}
+
+func scanPreproc(src string, start int) int {
+	i := start
+	for i < len(src) && src[i] != '\n' {
+		if src[i] == '\\' && i+1 < len(src) && src[i+1] == '\n' {
+			i += 2
+			continue
+		}
+		if strings.HasPrefix(src[i:], "/*") {
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return len(src) - start
+			}
+			i += end + 4
+			continue
+		}
+		i++
+	}
+	return i - start
+}
+
+func scanToEOL(src string, start int) int {
+	end := strings.IndexByte(src[start:], '\n')
+	if end < 0 {
+		return len(src) - start
+	}
+	return end
+}
+
+func scanQuoted(src string, start int) int {
+	quote := src[start]
+	i := start + 1
+	for i < len(src) && src[i] != quote && src[i] != '\n' {
+		if src[i] == '\\' {
+			i++
+		}
+		i++
+	}
+	if i < len(src) && src[i] == quote {
+		i++
+	}
+	if i > len(src) {
+		i = len(src)
+	}
+	return i - start
+}
+
+func scanNumber(src string, start int) int {
+	i := start
+	exponent := byte('e')
+	if strings.HasPrefix(strings.ToLower(src[start:min(start+2, len(src))]), "0x") {
+		exponent = 'p'
+	}
+	for i < len(src) {
+		c := src[i]
+		if isIdentChar(c) || c == '.' {
+			i++
+			continue
+		}
+		// Exponent sign, as in 1e-5 or 0x1p+3
+		if (c == '+' || c == '-') && i > start && src[i-1]|0x20 == exponent {
+			i++
+			continue
+		}
+		break
+	}
+	return i - start
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
@@ -0,0 +1,66 @@
+// lexer_test.go
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		kinds  []TokenKind
+		texts  []string
+	}{
+		{"empty", "", nil, nil},
+		{"declaration", "int x = 0x1F;", []TokenKind{TokKeyword, TokIdent, TokPunct, TokNumber, TokPunct}, []string{"int", "x", "=", "0x1F", ";"}},
+		{"punctuators", "a->b <<= 2", []TokenKind{TokIdent, TokPunct, TokIdent, TokPunct, TokNumber}, []string{"a", "->", "b", "<<=", "2"}},
+		{"directive", "#include <a.h>\nint", []TokenKind{TokPreproc, TokKeyword}, []string{"#include <a.h>", "int"}},
+		{"continued directive", "#define A \\\n 1\nx", []TokenKind{TokPreproc, TokIdent}, []string{"#define A \\\n 1", "x"}},
+		{"unterminated comment", "a /* b", []TokenKind{TokIdent, TokComment}, []string{"a", "/* b"}},
+		{"unterminated comment opener", "/*", []TokenKind{TokComment}, []string{"/*"}},
+		{"unterminated comment in directive", "#define A /* b", []TokenKind{TokPreproc}, []string{"#define A /* b"}},
+		{"unterminated string", "s = \"abc", []TokenKind{TokIdent, TokPunct, TokString}, []string{"s", "=", "\"abc"}},
+		{"string ending with backslash", "\"abc\\", []TokenKind{TokString}, []string{"\"abc\\"}},
+		{"string cut by newline", "'a\nb", []TokenKind{TokChar, TokIdent}, []string{"'a", "b"}},
+		{"lone quote", "'", []TokenKind{TokChar}, []string{"'"}},
+		{"line comment at end", "x // y", []TokenKind{TokIdent, TokComment}, []string{"x", "// y"}},
+		{"truncated exponent", "1e", []TokenKind{TokNumber}, []string{"1e"}},
+		{"truncated hexadecimal", "0x", []TokenKind{TokNumber}, []string{"0x"}},
+		{"lone hash", "#", []TokenKind{TokPreproc}, []string{"#"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens := tokenize(tt.source)
+			var kinds []TokenKind
+			var texts []string
+			for _, tok := range tokens {
+				if tok.Offset < 0 || tok.End() > len(tt.source) || tt.source[tok.Offset:tok.End()] != tok.Text {
+					t.Fatalf("token %+v does not match the source %q", tok, tt.source)
+				}
+				kinds = append(kinds, tok.Kind)
+				texts = append(texts, tok.Text)
+			}
+			if !reflect.DeepEqual(kinds, tt.kinds) || !reflect.DeepEqual(texts, tt.texts) {
+				t.Errorf("tokenize(%q) = %q %v, want %q %v", tt.source, texts, kinds, tt.texts, tt.kinds)
+			}
+		})
+	}
+}
+
+func TestTokenPositions(t *testing.T) {
+	tokens := tokenize("int a;\n\t/* b\n c */ x")
+	want := [][2]int{{1, 1}, {1, 5}, {1, 6}, {2, 2}, {3, 7}}
+	if len(tokens) != len(want) {
+		t.Fatalf("got %d tokens, want %d", len(tokens), len(want))
+	}
+	for i, tok := range tokens {
+		if tok.Line != want[i][0] || tok.Col != want[i][1] {
+			t.Errorf("token %q at %d:%d, want %d:%d", tok.Text, tok.Line, tok.Col, want[i][0], want[i][1])
+		}
+	}
+	if line, col := tokens[3].EndPosition(); line != 3 || col != 6 {
+		t.Errorf("comment ends at %d:%d, want 3:6", line, col)
+	}
+}
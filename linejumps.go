@@ -0,0 +1,126 @@
+// linejumps.go
+package main
+
+import "strings"
+
+func checkLineJumps(analysis *FileAnalysis, filename string, lineNum int) []Violation {
+	var violations []Violation
+	report := func(line int, id string, args ...any) {
+		violations = append(violations, Violation{
+			Rule:      "C-G2",
+			MessageID: id,
+			Args:      args,
+			Line:      line,
+			Severity:  SeverityMinor,
+		})
+	}
+
+	code := analysis.Code
+	previousEnd := 0
+	for index, fn := range analysis.FunctionDecls {
+		if fn.Prototype || fn.Nested || fn.BodyClose >= len(code) {
+			continue
+		}
+		defLine := code[fn.Start].Line
+		firstLine := defLine
+
+		if comment, ok := headerComment(analysis, fn); ok {
+			endLine := comment.Line + strings.Count(comment.Text, "\n")
+			if endLine < defLine && countBlankLines(analysis.Lines, endLine+1, defLine-1) > 0 {
+				report(endLine+1, "blank-line-after-comment", fn.Name.Text)
+			}
+			firstLine = comment.Line
+		}
+
+		if previousEnd > 0 && previousEnd < firstLine {
+			blanks := countBlankLines(analysis.Lines, previousEnd+1, firstLine-1)
+			if blanks == firstLine-previousEnd-1 && blanks != 1 {
+				report(firstLine, "function-separation", fn.Name.Text, blanks)
+			}
+		}
+		previousEnd = code[fn.BodyClose].Line
+
+		violations = append(violations, checkBodyLineJumps(analysis, index, fn)...)
+	}
+	return violations
+}
+
+// checkBodyLineJumps requires a single blank line right after the local
+// declarations of a function, and none anywhere else in its body.
+func checkBodyLineJumps(analysis *FileAnalysis, index int, fn FunctionDecl) []Violation {
+	var violations []Violation
+	code := analysis.Code
+	lines := analysis.Lines
+
+	// Walk the declaration statements opening the body
+	pos := fn.BodyOpen + 1
+	lastDecl := -1
+	for {
+		found := false
+		for _, decl := range analysis.Declarations {
+			if decl.Function == index && decl.Kind == DeclLocal && decl.Start == pos {
+				lastDecl = decl.End
+				pos = decl.End + 1
+				found = true
+				break
+			}
+		}
+		if !found {
+			break
+		}
+	}
+
+	separator := 0
+	if lastDecl >= 0 && pos < fn.BodyClose {
+		separator = code[lastDecl].Line + 1
+		if separator <= len(lines) && strings.TrimSpace(lines[separator-1]) != "" {
+			violations = append(violations, Violation{
+				Rule:      "C-G2",
+				MessageID: "missing-blank-after-declarations",
+				Args:      []any{fn.Name.Text},
+				Line:      separator,
+				Severity:  SeverityMinor,
+			})
+		}
+	}
+
+	// A blank line following another one is left to C-L2, which reports
+	// consecutive empty lines
+	for line := code[fn.BodyOpen].Line + 1; line < code[fn.BodyClose].Line; line++ {
+		if line == separator || strings.TrimSpace(lines[line-1]) != "" || strings.TrimSpace(lines[line-2]) == "" {
+			continue
+		}
+		violations = append(violations, Violation{
+			Rule:      "C-G2",
+			MessageID: "blank-line-in-function",
+			Args:      []any{fn.Name.Text},
+			Line:      line,
+			Severity:  SeverityMinor,
+		})
+	}
+	return violations
+}
+
+// headerComment returns the comment immediately preceding a function
+// definition, with only whitespace between them. The comment opening the
+// file is the file header, not the header of its first function.
+func headerComment(analysis *FileAnalysis, fn FunctionDecl) (Token, bool) {
+	start := analysis.Code[fn.Start].Offset
+	index := tokenIndex(analysis.Tokens, start)
+	if index <= 1 {
+		return Token{}, false
+	}
+	prev := analysis.Tokens[index-1]
+	return prev, prev.Kind == TokComment
+}
+
+// countBlankLines counts the blank lines between first and last, inclusive.
+func countBlankLines(lines []string, first, last int) int {
+	count := 0
+	for line := first; line <= last && line <= len(lines); line++ {
+		if line >= 1 && strings.TrimSpace(lines[line-1]) == "" {
+			count++
+		}
+	}
+	return count
+}
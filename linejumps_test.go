@@ -0,0 +1,90 @@
+// linejumps_test.go
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+const epitechHeader = `/*
+** EPITECH PROJECT, 2024
+** my_strlen
+** File description:
+** my_strlen
+*/
+`
+
+// lineJumps returns "line rule" for the C-G2 and C-L2 violations of a
+// source, sorted. The final newline of the sources is not reported.
+func lineJumps(source string) []string {
+	analysis := NewAnalyzer(2, DefaultConfig()).newFileAnalysis("test.c", []byte(source))
+	var got []string
+	for _, v := range append(checkLineJumps(analysis, "test.c", 0), checkEmptyLines(analysis, "test.c", 0)...) {
+		if v.Line < len(analysis.Lines) {
+			got = append(got, fmt.Sprintf("%d %s", v.Line, v.Rule))
+		}
+	}
+	sort.Strings(got)
+	return got
+}
+
+func TestCheckLineJumps(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   []string
+	}{
+		{
+			name:   "file header above the first function",
+			source: epitechHeader + "\nint my_strlen(char const *str)\n{\n\tint i = 0;\n\n\twhile (str[i])\n\t\ti++;\n\treturn i;\n}\n",
+		},
+		{
+			name:   "file header and includes",
+			source: epitechHeader + "\n#include <stddef.h>\n\nint foo(void)\n{\n\treturn 0;\n}\n",
+		},
+		{
+			name:   "function header comment",
+			source: epitechHeader + "\n/* Returns zero */\nint foo(void)\n{\n\treturn 0;\n}\n",
+		},
+		{
+			name:   "blank line after the function header comment",
+			source: epitechHeader + "\n/* Returns zero */\n\nint foo(void)\n{\n\treturn 0;\n}\n",
+			want:   []string{"9 C-G2"},
+		},
+		{
+			name:   "functions not separated",
+			source: "int foo(void)\n{\n\treturn 0;\n}\nint bar(void)\n{\n\treturn 1;\n}\n",
+			want:   []string{"5 C-G2"},
+		},
+		{
+			name:   "declarations not separated",
+			source: "int foo(void)\n{\n\tint i = 0;\n\treturn i;\n}\n",
+			want:   []string{"4 C-G2"},
+		},
+		{
+			name:   "blank line in the instructions",
+			source: "int foo(void)\n{\n\tfoo();\n\n\treturn 0;\n}\n",
+			want:   []string{"4 C-G2"},
+		},
+		{
+			// The second blank line is only reported once, by C-L2
+			name:   "consecutive blank lines in the instructions",
+			source: "int foo(void)\n{\n\tfoo();\n\n\n\treturn 0;\n}\n",
+			want:   []string{"4 C-G2", "5 C-L2"},
+		},
+		{
+			name:   "consecutive blank lines after the declarations",
+			source: "int foo(void)\n{\n\tint i = 0;\n\n\n\treturn i;\n}\n",
+			want:   []string{"5 C-L2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lineJumps(tt.source); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// magic.go
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+var defaultAllowedNumbers = []string{"0", "1", "-1"}
+
+// checkMagicNumbers flags numeric literals used in function bodies, apart
+// from the allowed values. Literals in macros, enum initializers and const
+// declarations name their value and are accepted.
+func checkMagicNumbers(analysis *FileAnalysis, filename string, lineNum int) []Violation {
+	var violations []Violation
+	allowed := analysis.Config.Allowed("C-G4", defaultAllowedNumbers)
+	allowedValues := make(map[float64]bool)
+	for _, value := range allowed {
+		if n, ok := parseNumber(value); ok {
+			allowedValues[n] = true
+		}
+	}
+
+	code := analysis.Code
+	exempt := make([]bool, len(code))
+	for _, decl := range analysis.Declarations {
+		if decl.Const || decl.Kind == DeclEnumConstant || decl.Kind == DeclTag {
+			for k := decl.Start; k <= decl.End && k < len(code); k++ {
+				exempt[k] = true
+			}
+		}
+	}
+
+	for _, fn := range analysis.FunctionDecls {
+		if fn.Prototype || fn.Nested {
+			continue
+		}
+		for k := fn.BodyOpen + 1; k < fn.BodyClose && k < len(code); k++ {
+			tok := code[k]
+			if tok.Kind != TokNumber || exempt[k] {
+				continue
+			}
+			text := tok.Text
+			if k > 0 && code[k-1].Text == "-" && (k < 2 || !isOperandEnd(code, k-2)) {
+				text = "-" + text
+			}
+			if value, ok := parseNumber(text); ok && allowedValues[value] {
+				continue
+			}
+			violations = append(violations, atToken(Violation{
+				Rule:      "C-G4",
+				MessageID: "magic-number",
+				Args:      []any{text, strings.TrimSpace(analysis.Lines[tok.Line-1])},
+				Line:      tok.Line,
+				Severity:  SeverityMinor,
+			}, tok))
+		}
+	}
+	return violations
+}
+
+// parseNumber reads a C integer or floating literal, suffixes included.
+func parseNumber(text string) (float64, bool) {
+	negative := strings.HasPrefix(text, "-")
+	text = strings.TrimPrefix(text, "-")
+	lower := strings.ToLower(text)
+	isHex := strings.HasPrefix(lower, "0x")
+	suffixes := "ul"
+	if !isHex {
+		suffixes = "ulf"
+	}
+	lower = strings.TrimRight(lower, suffixes)
+
+	var value float64
+	if n, err := strconv.ParseInt(lower, 0, 64); err == nil {
+		value = float64(n)
+	} else if f, err := strconv.ParseFloat(lower, 64); err == nil {
+		value = f
+	} else {
+		return 0, false
+	}
+	if negative {
+		value = -value
+	}
+	return value, true
+}
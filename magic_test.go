@@ -0,0 +1,46 @@
+// magic_test.go
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckMagicNumbers(t *testing.T) {
+	source := "#define SIZE 42\n" +
+		"enum { LIMIT = 12 };\n" +
+		"static const int MAX = 80;\n" +
+		"int foo(int a)\n" +
+		"{\n" +
+		"\tconst int width = 3;\n" +
+		"\n" +
+		"\tif (a > 0 && a != -1)\n" +
+		"\t\treturn a * 42;\n" +
+		"\treturn 0x10 + 2.5 + 1;\n" +
+		"}\n"
+	tests := []struct {
+		name    string
+		allowed []string // nil keeps the default values
+		lines   []int
+	}{
+		{"default values", nil, []int{9, 10, 10}},
+		{"configured values", []string{"0", "42", "16"}, []int{8, 10, 10}},
+		{"nothing allowed", []string{}, []int{8, 8, 9, 10, 10, 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			if tt.allowed != nil {
+				config.Rules["C-G4"] = RuleConfig{Allowed: tt.allowed}
+			}
+			analysis := NewAnalyzer(2, config).newFileAnalysis("test.c", []byte(source))
+			var lines []int
+			for _, v := range checkMagicNumbers(analysis, "test.c", 0) {
+				lines = append(lines, v.Line)
+			}
+			if !reflect.DeepEqual(lines, tt.lines) {
+				t.Errorf("violations on lines %v, want %v", lines, tt.lines)
+			}
+		})
+	}
+}
@@ -3,16 +3,18 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 )
 
-const (
-	// Colors
+// Colors, cleared by SetColor when the output does not support them
+var (
 	ColorReset  = "\033[0m"
 	ColorRed    = "\033[31m"
 	ColorGreen  = "\033[32m"
@@ -25,95 +27,244 @@ const (
 )
 
 type Violation struct {
-	Rule        string `json:"rule"`
-	Message     string `json:"message"`
-	Line        int    `json:"line"`
-	Severity    string `json:"severity"`
-	Description string `json:"description"`
+	Rule        string   `json:"rule"`
+	MessageID   string   `json:"message_id"`
+	Message     string   `json:"message"`
+	Line        int      `json:"line"`
+	Column      int      `json:"column"`
+	EndLine     int      `json:"end_line"`
+	EndColumn   int      `json:"end_column"`
+	Severity    Severity `json:"severity"`
+	Description string   `json:"description"`
+	Args        []any    `json:"args,omitempty"` // of the description, independent of the language
+	Fix         *Fix     `json:"fix,omitempty"`
 }
 
 type FileResult struct {
-	Filename   string      `json:"filename"`
-	Violations []Violation `json:"violations"`
-	Score      float64     `json:"score"`
-	LineCount  int         `json:"line_count"`
+	Filename   string       `json:"filename"`
+	Violations []Violation  `json:"violations"`
+	Score      float64      `json:"score"`
+	LineCount  int          `json:"line_count"`
+	Metrics    *FileMetrics `json:"metrics,omitempty"`
+
+	// Path of the file to open in an editor and source lines with their
+	// tabs expanded, kept for the verbose report and the TUI
+	Path  string   `json:"-"`
+	Lines []string `json:"-"`
 }
 
 type Report struct {
-	Files            []FileResult `json:"files"`
-	TotalScore       float64      `json:"total_score"`
-	TotalFiles       int          `json:"total_files"`
-	TotalLines       int          `json:"total_lines"`
-	TotalViolations  int          `json:"total_violations"`
-	CleanFiles       int          `json:"clean_files"`
+	Files           []FileResult `json:"files"`
+	TotalScore      float64      `json:"total_score"`
+	TotalFiles      int          `json:"total_files"`
+	TotalLines      int          `json:"total_lines"`
+	TotalViolations int          `json:"total_violations"`
+	CleanFiles      int          `json:"clean_files"`
+	Scoring         string       `json:"scoring"`
+	Errors          []FileError  `json:"errors"`
 }
 
 func main() {
+	if code, ok := runCommand(os.Args[1:]); ok {
+		os.Exit(code)
+	}
+	// "tui" takes the options of an analysis and browses its report
+	args := os.Args[1:]
+	tuiMode := len(args) > 0 && args[0] == "tui"
+	if tuiMode {
+		warnShadowedPath("tui")
+		args = args[1:]
+	}
+
 	var (
-		pathFlag    = flag.String("path", "", "Path to file or directory to analyze")
-		verboseFlag = flag.Bool("verbose", false, "Verbose output")
-		jsonFlag    = flag.Bool("json", false, "JSON output format")
-		silentFlag  = flag.Bool("silent", false, "Silent mode (exit code only)")
-		levelFlag   = flag.Int("level", 1, "Verification level (1=basic, 2=advanced)")
+		pathFlag          = flag.String("path", "", "Path to file or directory to analyze")
+		verboseFlag       = flag.Bool("verbose", false, "Verbose output")
+		jsonFlag          = flag.Bool("json", false, "JSON output format")
+		silentFlag        = flag.Bool("silent", false, "Silent mode (exit code only)")
+		levelFlag         = flag.Int("level", 1, "Verification level (1=basic, 2=advanced)")
+		configFlag        = flag.String("config", "", "Path to JSON configuration file (default: "+DefaultConfigFile+" if present)")
+		fixFlag           = flag.Bool("fix", false, "Automatically fix the violations that support it")
+		allowedFlag       = flag.String("allowed-functions", "", "File listing the only functions the project may call")
+		deliveryFlag      = flag.Bool("delivery", false, "Also check the project tree for files that must not be delivered")
+		scoringFlag       = flag.String("scoring", "", "Scoring model: "+scoringNames()+" (default: "+DefaultScoring+")")
+		failOnFlag        = flag.String("fail-on", "minor", "Lowest severity failing the run: major, minor or info")
+		maxViolationsFlag = flag.Int("max-violations", 0, "Number of failing violations tolerated")
+		minScoreFlag      = flag.Float64("min-score", 0, "Fail when the global score is below this value")
+		hiddenFlag        = flag.Bool("hidden", false, "Also analyze the directories whose name starts with '.'")
+		filesFromFlag     = flag.String("files-from", "", "File listing the paths to analyze, one per line ('-' for stdin)")
+		stdinNameFlag     = flag.String("stdin-filename", "", "Analyze the source read from stdin as this file")
+		langFlag          = flag.String("lang", "", "Language of the messages: "+languageNames()+" (default: from LANG)")
+		formatFlag        = flag.String("format", "", "Output format: pretty, text (one line per violation) or json (default: pretty)")
+		colorFlag         = flag.String("color", "auto", "Colored output: auto, always or never")
+		asciiFlag         = flag.Bool("ascii", false, "Draw the report with ASCII characters only (default: from the locale and TERM)")
+		includeFlags      stringList
+		excludeFlags      stringList
 	)
-	flag.Parse()
+	flag.Var(&includeFlags, "include", "Only analyze the files matching this glob (repeatable)")
+	flag.Var(&excludeFlags, "exclude", "Skip the files and directories matching this glob (repeatable)")
+	flag.CommandLine.Parse(args)
 
-	// Get path from flag or argument
-	path := *pathFlag
-	if path == "" && len(flag.Args()) > 0 {
-		path = flag.Args()[0]
+	if err := SetLanguage(*langFlag); err != nil {
+		exitOnError(err, ExitUsage)
 	}
+	if err := SetColor(*colorFlag, os.Stdout); err != nil {
+		exitOnError(err, ExitUsage)
+	}
+	ascii := detectASCII()
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "ascii" {
+			ascii = *asciiFlag
+		}
+	})
+	SetASCII(ascii)
+	format := *formatFlag
+	if *jsonFlag {
+		format = "json"
+	}
+	switch format {
+	case "", "pretty", "text", "json":
+	default:
+		exitOnError(fmt.Errorf("unknown output format '%s' (available: pretty, text, json)", format), ExitUsage)
+	}
+	failOn, err := ParseSeverity(*failOnFlag)
+	if err != nil {
+		exitOnError(err, ExitUsage)
+	}
+	policy := FailPolicy{FailOn: failOn, MaxViolations: *maxViolationsFlag}
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "min-score" {
+			policy.MinScore = minScoreFlag
+		}
+	})
 
-	if path == "" {
-		fmt.Fprintf(os.Stderr, "Usage: %s [options] <file_or_directory>\n", os.Args[0])
+	// Get paths from flag, arguments and list file
+	var paths []string
+	if *pathFlag != "" {
+		paths = append(paths, *pathFlag)
+	}
+	paths = append(paths, flag.Args()...)
+	if *filesFromFlag != "" {
+		if *filesFromFlag == "-" && *stdinNameFlag != "" {
+			exitOnError(errors.New("-files-from - and -stdin-filename both read stdin"), ExitUsage)
+		}
+		listed, err := readPathListFile(*filesFromFlag)
+		if err != nil {
+			exitOnError(err, ExitUsage)
+		}
+		paths = append(paths, listed...)
+	}
+	if tuiMode && (*stdinNameFlag != "" || *filesFromFlag == "-") {
+		exitOnError(errors.New("tui reads the keyboard on stdin, which cannot also provide sources"), ExitUsage)
+	}
+	if *stdinNameFlag != "" && (len(paths) > 0 || *fixFlag) {
+		exitOnError(errors.New("-stdin-filename cannot be combined with paths or -fix"), ExitUsage)
+	}
+
+	if len(paths) == 0 && *stdinNameFlag == "" && *filesFromFlag == "" {
+		fmt.Fprintf(os.Stderr, "Usage: %s [options] <file_or_directory>...\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s rules [-config file] [-level n]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s explain <rule_code>\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s tui [options] <file_or_directory>...\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "A path named like a subcommand is analyzed when written as ./rules, ./explain, ...\n")
 		flag.PrintDefaults()
-		os.Exit(1)
+		os.Exit(ExitUsage)
 	}
 
-	analyzer := NewAnalyzer(*levelFlag)
-	report, err := analyzer.AnalyzePath(path)
+	config, err := loadConfigFile(*configFlag)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		exitOnError(err, ExitUsage)
+	}
+	if *allowedFlag != "" {
+		names, err := readNameList(*allowedFlag)
+		if err != nil {
+			exitOnError(err, ExitUsage)
+		}
+		config.SetAllowed("C-G5", names)
+	}
+	if *deliveryFlag {
+		config.Enable("C-O4")
+	}
+	if *scoringFlag != "" {
+		if _, err := scoringModel(*scoringFlag); err != nil {
+			exitOnError(err, ExitUsage)
+		}
+		config.Scoring = *scoringFlag
 	}
 
-	if *silentFlag {
-		if report.TotalViolations > 0 {
-			os.Exit(1)
+	analyzer := NewAnalyzer(*levelFlag, config)
+	analyzer.SetFix(*fixFlag)
+	analyzer.SetWalkOptions(WalkOptions{Include: includeFlags, Exclude: excludeFlags, Hidden: *hiddenFlag})
+	var report *Report
+	if *stdinNameFlag != "" {
+		content, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			exitOnError(err, ExitIOError)
 		}
-		os.Exit(0)
+		report = analyzer.AnalyzeSource(*stdinNameFlag, content)
+	} else {
+		report = analyzer.AnalyzePaths(paths)
 	}
 
-	if *jsonFlag {
+	if tuiMode {
+		analyzer.SetFix(false)
+		report, err = runTUI(report, func() *Report { return analyzer.AnalyzePaths(paths) })
+		if err != nil {
+			exitOnError(err, ExitUsage)
+		}
+		os.Exit(exitCode(report, policy))
+	}
+
+	if *silentFlag {
+		os.Exit(exitCode(report, policy))
+	}
+
+	switch format {
+	case "json":
 		output, _ := json.MarshalIndent(report, "", "  ")
 		fmt.Println(string(output))
-	} else {
+	case "text":
+		printTextReport(report)
+	default:
 		printReport(report, *verboseFlag)
 	}
 
-	if report.TotalViolations > 0 {
-		os.Exit(1)
-	}
+	os.Exit(exitCode(report, policy))
 }
 
 type Analyzer struct {
-	level int
-	rules map[string]Rule
+	level   int
+	config  *Config
+	fix     bool
+	walk    WalkOptions
+	scoring ScoringModel
+	rules   map[string]Rule
 }
 
 type Rule struct {
 	Code        string
 	Name        string
 	Description string
-	Severity    string
+	Severity    Severity
 	Level       int
+	Optional    bool // only run when enabled in the configuration
 	Check       func(*FileAnalysis, string, int) []Violation
+
+	// CheckProject replaces Check for the rules comparing files together
+	CheckProject func([]*FileAnalysis, *Config) map[string][]Violation
 }
 
 type FileAnalysis struct {
-	Filename  string
-	Lines     []string
-	Functions []FunctionInfo
+	Filename      string
+	Source        string
+	Lines         []string
+	Tokens        []Token
+	Code          []Token
+	Functions     []FunctionInfo
+	FunctionDecls []FunctionDecl
+	Declarations  []Declaration
+	Config        *Config
+	Project       *Project
+	Metrics       *FileMetrics
 }
 
 type FunctionInfo struct {
@@ -123,118 +274,292 @@ type FunctionInfo struct {
 	ParamCount int
 }
 
-func NewAnalyzer(level int) *Analyzer {
+func NewAnalyzer(level int, config *Config) *Analyzer {
+	if config == nil {
+		config = DefaultConfig()
+	}
 	a := &Analyzer{
-		level: level,
-		rules: make(map[string]Rule),
+		level:  level,
+		config: config,
+		rules:  make(map[string]Rule),
 	}
+	scoring, err := scoringModel(config.Scoring)
+	if err != nil {
+		scoring = scoringModels[DefaultScoring]
+	}
+	a.scoring = scoring
 	a.initRules()
+	for code, rule := range a.rules {
+		rule.Name = tr("rule." + code)
+		rule.Description = tr("rule." + code + ".description")
+		a.rules[code] = rule
+	}
 	return a
 }
 
+// SetFix makes the analyzer rewrite files with the available fixes before
+// reporting the remaining violations.
+func (a *Analyzer) SetFix(fix bool) {
+	a.fix = fix
+}
+
+// SetWalkOptions selects the sources analyzed in directories.
+func (a *Analyzer) SetWalkOptions(options WalkOptions) {
+	a.walk = options
+}
+
+// initRules registers the rules, whose names and descriptions are taken
+// from the "rule." keys of the message catalogue.
 func (a *Analyzer) initRules() {
 	// Level 1 rules (basic)
 	a.rules["C-L1"] = Rule{
-		Code: "C-L1", Name: "Line Length", Description: "Line too long (80 chars max)",
-		Severity: "major", Level: 1, Check: checkLineLength,
+		Code: "C-L1", Severity: SeverityMajor, Level: 1, Check: checkLineLength,
 	}
 	a.rules["C-L2"] = Rule{
-		Code: "C-L2", Name: "Empty Lines", Description: "Forbidden empty lines",
-		Severity: "minor", Level: 1, Check: checkEmptyLines,
+		Code: "C-L2", Severity: SeverityMinor, Level: 1, Check: checkEmptyLines,
 	}
 	a.rules["C-L3"] = Rule{
-		Code: "C-L3", Name: "Indentation", Description: "TAB indentation only",
-		Severity: "major", Level: 1, Check: checkIndentation,
+		Code: "C-L3", Severity: SeverityMajor, Level: 1, Check: checkIndentation,
 	}
 	a.rules["C-L4"] = Rule{
-		Code: "C-L4", Name: "Variable Declaration", Description: "One variable per line",
-		Severity: "major", Level: 1, Check: checkVariableDeclaration,
+		Code: "C-L4", Severity: SeverityMajor, Level: 1, Check: checkVariableDeclaration,
 	}
 	a.rules["C-V1"] = Rule{
-		Code: "C-V1", Name: "Variable Position", Description: "Variables at function start",
-		Severity: "major", Level: 1, Check: checkVariablePosition,
+		Code: "C-V1", Severity: SeverityMajor, Level: 1, Check: checkVariablePosition,
 	}
 	a.rules["C-O1"] = Rule{
-		Code: "C-O1", Name: "Filename", Description: "Filename in snake_case",
-		Severity: "major", Level: 1, Check: checkFilename,
+		Code: "C-O1", Severity: SeverityMajor, Level: 1, Check: checkFilename,
 	}
 	a.rules["C-O2"] = Rule{
-		Code: "C-O2", Name: "Function Count", Description: "Max 3 functions per file",
-		Severity: "major", Level: 1, Check: checkFunctionCount,
+		Code: "C-O2", Severity: SeverityMajor, Level: 1, Check: checkFunctionCount,
 	}
 	a.rules["C-F1"] = Rule{
-		Code: "C-F1", Name: "Function Name", Description: "Function name in snake_case",
-		Severity: "major", Level: 1, Check: checkFunctionNames,
+		Code: "C-F1", Severity: SeverityMajor, Level: 1, Check: checkFunctionNames,
 	}
 	a.rules["C-F2"] = Rule{
-		Code: "C-F2", Name: "Macro Name", Description: "Macro in SCREAMING_SNAKE_CASE",
-		Severity: "major", Level: 1, Check: checkMacroNames,
+		Code: "C-F2", Severity: SeverityMajor, Level: 1, Check: checkMacroNames,
 	}
 	a.rules["C-F3"] = Rule{
-		Code: "C-F3", Name: "Function Length", Description: "Function max 25 lines",
-		Severity: "major", Level: 1, Check: checkFunctionLength,
+		Code: "C-F3", Severity: SeverityMajor, Level: 1, Check: checkFunctionLength,
+	}
+	a.rules["C-L6"] = Rule{
+		Code: "C-L6", Severity: SeverityMinor, Level: 1, Check: checkSpacing,
+	}
+	a.rules["C-L7"] = Rule{
+		Code: "C-L7", Severity: SeverityMinor, Level: 1, Check: checkBracePlacement,
+	}
+	a.rules["C-V2"] = Rule{
+		Code: "C-V2", Severity: SeverityMajor, Level: 1, Check: checkTypedefNames,
+	}
+	a.rules["C-V3"] = Rule{
+		Code: "C-V3", Severity: SeverityMajor, Level: 1, Check: checkTagNames,
+	}
+	a.rules["C-V4"] = Rule{
+		Code: "C-V4", Severity: SeverityMajor, Level: 1, Check: checkEnumConstantNames,
+	}
+	a.rules["C-V5"] = Rule{
+		Code: "C-V5", Severity: SeverityMajor, Level: 1, Check: checkGlobalConstantNames,
+	}
+	a.rules["C-V6"] = Rule{
+		Code: "C-V6", Severity: SeverityMajor, Level: 1, Check: checkLocalVariableNames,
+	}
+	a.rules["C-V7"] = Rule{
+		Code: "C-V7", Severity: SeverityMajor, Level: 1, Check: checkParameterNames,
+	}
+	a.rules["C-V8"] = Rule{
+		Code: "C-V8", Severity: SeverityMinor, Level: 1, Check: checkPointerDeclarations,
+	}
+	a.rules["C-F5"] = Rule{
+		Code: "C-F5", Severity: SeverityMajor, Level: 1, Check: checkEmptyParameterList,
 	}
 
 	// Level 2 rules (advanced)
 	if a.level >= 2 {
 		a.rules["C-C1"] = Rule{
-			Code: "C-C1", Name: "Comment Format", Description: "/* */ comments only",
-			Severity: "minor", Level: 2, Check: checkCommentFormat,
+			Code: "C-C1", Severity: SeverityMinor, Level: 2, Check: checkCommentFormat,
 		}
 		a.rules["C-C2"] = Rule{
-			Code: "C-C2", Name: "Function Comment", Description: "Function comment required",
-			Severity: "minor", Level: 2, Check: checkFunctionComment,
+			Code: "C-C2", Severity: SeverityMinor, Level: 2, Check: checkFunctionComment,
+		}
+		a.rules["C-F7"] = Rule{
+			Code: "C-F7", Severity: SeverityMinor, Level: 2, Check: checkCommentsInFunctions,
 		}
 		a.rules["C-G1"] = Rule{
-			Code: "C-G1", Name: "Global Variables", Description: "No non-const globals",
-			Severity: "major", Level: 2, Check: checkGlobalVariables,
+			Code: "C-G1", Severity: SeverityMajor, Level: 2, Check: checkGlobalVariables,
+		}
+		a.rules["C-G2"] = Rule{
+			Code: "C-G2", Severity: SeverityMinor, Level: 2, Check: checkLineJumps,
+		}
+		a.rules["C-G3"] = Rule{
+			Code: "C-G3", Severity: SeverityMinor, Level: 2, Check: checkPreprocessor,
+		}
+		a.rules["C-G4"] = Rule{
+			Code: "C-G4", Severity: SeverityMinor, Level: 2, Check: checkMagicNumbers,
 		}
 		a.rules["C-F4"] = Rule{
-			Code: "C-F4", Name: "Function Parameters", Description: "Max 4 parameters",
-			Severity: "major", Level: 2, Check: checkFunctionParameters,
+			Code: "C-F4", Severity: SeverityMajor, Level: 2, Check: checkFunctionParameters,
+		}
+		a.rules["C-F6"] = Rule{
+			Code: "C-F6", Severity: SeverityMajor, Level: 2, Check: checkStructureParameters,
+		}
+		a.rules["C-F8"] = Rule{
+			Code: "C-F8", Severity: SeverityMajor, Level: 2, Check: checkNestedFunctions,
+		}
+		a.rules["C-F9"] = Rule{
+			Code: "C-F9", Severity: SeverityMinor, Level: 2, Check: checkUnusedStaticFunctions,
 		}
 		a.rules["C-L5"] = Rule{
-			Code: "C-L5", Name: "For Loop Declaration", Description: "No declaration in for loops",
-			Severity: "major", Level: 2, Check: checkForLoopDeclaration,
+			Code: "C-L5", Severity: SeverityMajor, Level: 2, Check: checkForLoopDeclaration,
 		}
 	}
+
+	// Project rules, only active once the configuration lists names
+	a.rules["C-G5"] = Rule{
+		Code: "C-G5", Severity: SeverityMajor, Level: 1, Check: checkForbiddenFunctions,
+	}
+	a.rules["C-G6"] = Rule{
+		Code: "C-G6", Severity: SeverityMajor, Level: 1, Check: checkForbiddenHeaders,
+	}
+
+	// Optional rules, enabled from the configuration
+	a.rules["C-F10"] = Rule{
+		Code: "C-F10", Severity: SeverityMajor, Level: 1, Optional: true, Check: checkComplexity,
+	}
+	a.rules["C-O3"] = Rule{
+		Code: "C-O3", Severity: SeverityMajor, Level: 1, Optional: true, CheckProject: checkDuplicateCode,
+	}
+
+	// Checked on the project tree by AnalyzePath rather than on sources
+	a.rules["C-O4"] = Rule{
+		Code: "C-O4", Severity: SeverityMajor, Level: 1, Optional: true,
+	}
 }
 
-func (a *Analyzer) AnalyzePath(path string) (*Report, error) {
-	var files []string
-	
-	info, err := os.Stat(path)
-	if err != nil {
-		return nil, err
+// AnalyzePaths analyzes files and directories together, as one project.
+// Paths that cannot be analyzed are recorded in the errors of the report.
+func (a *Analyzer) AnalyzePaths(paths []string) *Report {
+	report := a.newReport()
+	addError := func(filename string, err error) {
+		report.Errors = append(report.Errors, newFileError(filename, err))
 	}
 
-	if info.IsDir() {
-		err = filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+	var files, dirs []string
+	seen := make(map[string]bool)
+	addFile := func(file string) {
+		if !seen[filepath.Clean(file)] {
+			seen[filepath.Clean(file)] = true
+			files = append(files, file)
+		}
+	}
+	covered := coveredDirs(paths)
+	for i, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			addError(path, err)
+			continue
+		}
+		if info.IsDir() {
+			if covered[i] {
+				continue
+			}
+			sources, err := collectSources(path, a.walk, addError)
 			if err != nil {
-				return err
+				addError(path, err)
 			}
-			if strings.HasSuffix(p, ".c") || strings.HasSuffix(p, ".h") {
-				files = append(files, p)
+			for _, file := range sources {
+				addFile(file)
 			}
-			return nil
-		})
+			dirs = append(dirs, path)
+		} else if isSourceFile(path) {
+			addFile(path)
+		} else {
+			addError(path, errors.New("not a C source file (.c or .h) or a directory"))
+		}
+	}
+
+	var analyses []*FileAnalysis
+	for _, file := range files {
+		content, err := readSource(file)
 		if err != nil {
-			return nil, err
+			addError(file, err)
+			continue
 		}
-	} else if strings.HasSuffix(path, ".c") || strings.HasSuffix(path, ".h") {
-		files = append(files, path)
+		analyses = append(analyses, a.newFileAnalysis(file, content))
 	}
+	a.analyzeProject(report, analyses)
 
-	report := &Report{
-		Files: make([]FileResult, 0, len(files)),
+	if rule := a.rules["C-O4"]; a.isActive(rule) {
+		for _, dir := range dirs {
+			artifacts, err := checkDelivery(dir)
+			if err != nil {
+				addError(dir, err)
+			}
+			for i := range artifacts {
+				a.overrideSeverity(artifacts[i].Violations)
+				localize(artifacts[i].Violations)
+				a.score(&artifacts[i])
+			}
+			report.Files = append(report.Files, artifacts...)
+		}
 	}
 
-	for _, file := range files {
-		result, err := a.analyzeFile(file)
+	a.summarize(report)
+	return report
+}
+
+// AnalyzeSource analyzes a source held in memory, such as an editor buffer
+// read from stdin, as if it were the given file.
+func (a *Analyzer) AnalyzeSource(filename string, content []byte) *Report {
+	report := a.newReport()
+	if err := checkSource(content); err != nil {
+		report.Errors = append(report.Errors, newFileError(filename, err))
+	} else {
+		a.analyzeProject(report, []*FileAnalysis{a.newFileAnalysis(filename, content)})
+	}
+	a.summarize(report)
+	return report
+}
+
+func (a *Analyzer) newReport() *Report {
+	return &Report{
+		Files:   make([]FileResult, 0),
+		Scoring: a.scoring.Name,
+		Errors:  make([]FileError, 0),
+	}
+}
+
+// analyzeProject runs the rules on the files, then the rules comparing
+// them with each other, and adds the results to the report.
+func (a *Analyzer) analyzeProject(report *Report, analyses []*FileAnalysis) {
+	project := NewProject(analyses)
+
+	var analyzed []*FileAnalysis
+	var results []FileResult
+	for _, analysis := range analyses {
+		analysis.Project = project
+		result, err := a.analyzeFile(analysis)
 		if err != nil {
+			report.Errors = append(report.Errors, newFileError(analysis.Filename, err))
 			continue
 		}
-		report.Files = append(report.Files, *result)
+		results = append(results, *result)
+		analyzed = append(analyzed, analysis)
+	}
+
+	// Rules comparing the files with each other
+	projectViolations := a.runProjectRules(analyzed)
+	for i, analysis := range analyzed {
+		if extra := projectViolations[analysis.Filename]; len(extra) > 0 {
+			results[i].Violations = append(results[i].Violations, extra...)
+			a.score(&results[i])
+		}
+	}
+	report.Files = append(report.Files, results...)
+}
+
+// summarize computes the totals and the global score of the report.
+func (a *Analyzer) summarize(report *Report) {
+	for _, result := range report.Files {
 		report.TotalFiles++
 		report.TotalLines += result.LineCount
 		report.TotalViolations += len(result.Violations)
@@ -245,70 +570,157 @@ func (a *Analyzer) AnalyzePath(path string) (*Report, error) {
 
 	// Calculate total score
 	if report.TotalFiles > 0 {
-		totalScore := 0.0
-		for _, file := range report.Files {
-			totalScore += file.Score
-		}
-		report.TotalScore = totalScore / float64(report.TotalFiles)
+		report.TotalScore = a.scoring.Total(report.Files)
 	}
-
-	return report, nil
 }
 
-func (a *Analyzer) analyzeFile(filename string) (*FileResult, error) {
-	content, err := os.ReadFile(filename)
+func (a *Analyzer) analyzeFile(analysis *FileAnalysis) (*FileResult, error) {
+	filename := analysis.Filename
+	violations, err := a.runRules(analysis)
 	if err != nil {
 		return nil, err
 	}
 
-	lines := strings.Split(string(content), "\n")
+	if a.fix {
+		fixed := applyFixes(analysis.Source, violations)
+		if fixed != analysis.Source {
+			if err := writeFixedFile(filename, fixed); err != nil {
+				return nil, err
+			}
+			fixedAnalysis := a.newFileAnalysis(filename, []byte(fixed))
+			fixedAnalysis.Project = analysis.Project
+			*analysis = *fixedAnalysis
+			if violations, err = a.runRules(analysis); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	result := &FileResult{
+		Filename:   filename,
+		Violations: violations,
+		LineCount:  len(analysis.Lines),
+		Metrics:    analysis.Metrics,
+		Path:       filename,
+		Lines:      expandTabs(analysis.Lines, analysis.Config.GetTabWidth()),
+	}
+	a.score(result)
+	return result, nil
+}
+
+func (a *Analyzer) newFileAnalysis(filename string, content []byte) *FileAnalysis {
+	source := string(content)
+	lines := strings.Split(source, "\n")
+	tokens := tokenize(source)
+	code := codeTokens(tokens)
+	functionDecls, declarations := parseDeclarations(code)
 	analysis := &FileAnalysis{
-		Filename: filename,
-		Lines:    lines,
-		Functions: extractFunctions(lines),
+		Filename:      filename,
+		Source:        source,
+		Lines:         lines,
+		Tokens:        tokens,
+		Code:          code,
+		Functions:     extractFunctions(lines),
+		FunctionDecls: functionDecls,
+		Declarations:  declarations,
+		Config:        a.config,
 	}
+	analysis.Metrics = computeMetrics(analysis)
+	return analysis
+}
+
+func (a *Analyzer) isActive(rule Rule) bool {
+	return rule.Level <= a.level && a.config.IsEnabled(rule.Code, !rule.Optional)
+}
 
+func (a *Analyzer) runRules(analysis *FileAnalysis) ([]Violation, error) {
 	var violations []Violation
+	tabWidth := a.config.GetTabWidth()
 	for _, rule := range a.rules {
-		if rule.Level <= a.level {
-			ruleViolations := rule.Check(analysis, filename, 0)
+		if rule.Check != nil && a.isActive(rule) {
+			ruleViolations, err := checkRule(rule, analysis)
+			if err != nil {
+				return nil, err
+			}
+			for i, v := range ruleViolations {
+				if v.Column == 0 && v.Line > 0 {
+					v = atLine(v, analysis.Lines)
+				}
+				ruleViolations[i] = atDisplayColumns(v, analysis.Lines, tabWidth)
+			}
+			a.overrideSeverity(ruleViolations)
+			localize(ruleViolations)
 			violations = append(violations, ruleViolations...)
 		}
 	}
+	return violations, nil
+}
 
-	// Calculate score (100 - penalty per violation)
-	score := 100.0
-	for _, v := range violations {
-		penalty := 5.0 // major violations
-		if v.Severity == "minor" {
-			penalty = 2.0
+// checkRule runs a rule on a file. A rule panicking is a bug of the rule,
+// not of the file: it fails the file with a RuleError naming the rule, so
+// that the other files are still analyzed.
+func checkRule(rule Rule, analysis *FileAnalysis) (violations []Violation, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			violations, err = nil, &RuleError{Rule: rule.Code, Panic: r}
 		}
-		score -= penalty
+	}()
+	return rule.Check(analysis, analysis.Filename, 0), nil
+}
+
+// runProjectRules runs the rules needing every file at once and returns
+// their violations indexed by filename.
+func (a *Analyzer) runProjectRules(analyses []*FileAnalysis) map[string][]Violation {
+	violations := make(map[string][]Violation)
+	lines := make(map[string][]string)
+	for _, analysis := range analyses {
+		lines[analysis.Filename] = analysis.Lines
 	}
-	if score < 0 {
-		score = 0
+	tabWidth := a.config.GetTabWidth()
+	for _, rule := range a.rules {
+		if rule.CheckProject == nil || !a.isActive(rule) {
+			continue
+		}
+		for filename, ruleViolations := range rule.CheckProject(analyses, a.config) {
+			a.overrideSeverity(ruleViolations)
+			localize(ruleViolations)
+			for _, v := range ruleViolations {
+				violations[filename] = append(violations[filename], atDisplayColumns(v, lines[filename], tabWidth))
+			}
+		}
 	}
+	return violations
+}
 
-	return &FileResult{
-		Filename:   filepath.Base(filename),
-		Violations: violations,
-		Score:      score,
-		LineCount:  len(lines),
-	}, nil
+// overrideSeverity applies the severities set in the configuration.
+func (a *Analyzer) overrideSeverity(violations []Violation) {
+	for i, v := range violations {
+		if severity, ok := a.config.Severity(v.Rule); ok {
+			violations[i].Severity = severity
+		}
+	}
+}
+
+// score sets the score of a file with the selected scoring model
+func (a *Analyzer) score(result *FileResult) {
+	result.Score = a.scoring.Score(result.Violations, result.LineCount, a.config)
 }
 
 // Rule checking functions
 func checkLineLength(analysis *FileAnalysis, filename string, lineNum int) []Violation {
 	var violations []Violation
+	tabWidth := analysis.Config.GetTabWidth()
 	for i, line := range analysis.Lines {
-		if len(line) > 80 {
-			violations = append(violations, Violation{
-				Rule:        "C-L1",
-				Message:     "Line too long",
-				Line:        i + 1,
-				Severity:    "major",
-				Description: fmt.Sprintf("Line contains %d characters (max 80)", len(line)),
-			})
+		columns := displayWidth(line, tabWidth)
+		if columns > 80 {
+			line = strings.TrimRight(line, "\r")
+			violations = append(violations, atColumns(Violation{
+				Rule:      "C-L1",
+				MessageID: "line-too-long",
+				Args:      []any{columns},
+				Line:      i + 1,
+				Severity:  SeverityMajor,
+			}, i+1, columnAt(line, 80, tabWidth), len(line)+1))
 		}
 	}
 	return violations
@@ -321,22 +733,20 @@ func checkEmptyLines(analysis *FileAnalysis, filename string, lineNum int) []Vio
 	// Check first line
 	if len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
 		violations = append(violations, Violation{
-			Rule:        "C-L2",
-			Message:     "Empty line at beginning of file",
-			Line:        1,
-			Severity:    "minor",
-			Description: "File should not start with empty line",
+			Rule:      "C-L2",
+			MessageID: "empty-line-at-start",
+			Line:      1,
+			Severity:  SeverityMinor,
 		})
 	}
 	
 	// Check last line
 	if len(lines) > 1 && strings.TrimSpace(lines[len(lines)-1]) == "" {
 		violations = append(violations, Violation{
-			Rule:        "C-L2",
-			Message:     "Empty line at end of file",
-			Line:        len(lines),
-			Severity:    "minor",
-			Description: "File should not end with empty line",
+			Rule:      "C-L2",
+			MessageID: "empty-line-at-end",
+			Line:      len(lines),
+			Severity:  SeverityMinor,
 		})
 	}
 	
@@ -344,11 +754,10 @@ func checkEmptyLines(analysis *FileAnalysis, filename string, lineNum int) []Vio
 	for i := 1; i < len(lines); i++ {
 		if strings.TrimSpace(lines[i]) == "" && strings.TrimSpace(lines[i-1]) == "" {
 			violations = append(violations, Violation{
-				Rule:        "C-L2",
-				Message:     "Consecutive empty lines",
-				Line:        i + 1,
-				Severity:    "minor",
-				Description: "Multiple consecutive empty lines are forbidden",
+				Rule:      "C-L2",
+				MessageID: "consecutive-empty-lines",
+				Line:      i + 1,
+				Severity:  SeverityMinor,
 			})
 		}
 	}
@@ -360,13 +769,13 @@ func checkIndentation(analysis *FileAnalysis, filename string, lineNum int) []Vi
 	var violations []Violation
 	for i, line := range analysis.Lines {
 		if len(line) > 0 && line[0] == ' ' {
-			violations = append(violations, Violation{
-				Rule:        "C-L3",
-				Message:     "Space indentation",
-				Line:        i + 1,
-				Severity:    "major",
-				Description: "Use TAB for indentation, not spaces",
-			})
+			indent := len(line) - len(strings.TrimLeft(line, " \t"))
+			violations = append(violations, atColumns(Violation{
+				Rule:      "C-L3",
+				MessageID: "space-indentation",
+				Line:      i + 1,
+				Severity:  SeverityMajor,
+			}, i+1, 1, indent+1))
 		}
 	}
 	return violations
@@ -381,11 +790,10 @@ func checkVariableDeclaration(analysis *FileAnalysis, filename string, lineNum i
 		   strings.Contains(trimmed, "float ") || strings.Contains(trimmed, "double ") {
 			if strings.Count(trimmed, ",") > 0 && !strings.Contains(trimmed, "for") {
 				violations = append(violations, Violation{
-					Rule:        "C-L4",
-					Message:     "Multiple variable declaration",
-					Line:        i + 1,
-					Severity:    "major",
-					Description: "Declare only one variable per line",
+					Rule:      "C-L4",
+					MessageID: "multiple-variable-declaration",
+					Line:      i + 1,
+					Severity:  SeverityMajor,
 				})
 			}
 		}
@@ -405,11 +813,10 @@ func checkFilename(analysis *FileAnalysis, filename string, lineNum int) []Viola
 	
 	if !isSnakeCase(name) {
 		violations = append(violations, Violation{
-			Rule:        "C-O1",
-			Message:     "Invalid filename format",
-			Line:        0,
-			Severity:    "major",
-			Description: "Filename must be in snake_case",
+			Rule:      "C-O1",
+			MessageID: "invalid-filename",
+			Line:      0,
+			Severity:  SeverityMajor,
 		})
 	}
 	return violations
@@ -434,11 +841,11 @@ func checkFunctionCount(analysis *FileAnalysis, filename string, lineNum int) []
 	
 	if funcCount > 3 {
 		violations = append(violations, Violation{
-			Rule:        "C-O2",
-			Message:     "Too many functions",
-			Line:        0,
-			Severity:    "major",
-			Description: fmt.Sprintf("File contains %d functions (max 3 excluding main)", funcCount),
+			Rule:      "C-O2",
+			MessageID: "too-many-functions",
+			Args:      []any{funcCount},
+			Line:      0,
+			Severity:  SeverityMajor,
 		})
 	}
 	return violations
@@ -448,13 +855,13 @@ func checkFunctionNames(analysis *FileAnalysis, filename string, lineNum int) []
 	var violations []Violation
 	for _, fn := range analysis.Functions {
 		if !isSnakeCase(fn.Name) && fn.Name != "main" {
-			violations = append(violations, Violation{
-				Rule:        "C-F1",
-				Message:     "Invalid function name",
-				Line:        fn.StartLine,
-				Severity:    "major",
-				Description: fmt.Sprintf("Function '%s' must be in snake_case", fn.Name),
-			})
+			violations = append(violations, atWord(Violation{
+				Rule:      "C-F1",
+				MessageID: "invalid-function-name",
+				Args:      []any{fn.Name},
+				Line:      fn.StartLine,
+				Severity:  SeverityMajor,
+			}, analysis.Lines, fn.Name))
 		}
 	}
 	return violations
@@ -463,20 +870,17 @@ func checkFunctionNames(analysis *FileAnalysis, filename string, lineNum int) []
 func checkMacroNames(analysis *FileAnalysis, filename string, lineNum int) []Violation {
 	var violations []Violation
 	for i, line := range analysis.Lines {
-		trimmed := strings.TrimSpace(line)
-		if strings.HasPrefix(trimmed, "#define ") {
-			parts := strings.Fields(trimmed)
-			if len(parts) >= 2 {
-				macroName := parts[1]
-				if !isScreamingSnakeCase(macroName) {
-					violations = append(violations, Violation{
-						Rule:        "C-F2",
-						Message:     "Invalid macro name",
-						Line:        i + 1,
-						Severity:    "major",
-						Description: fmt.Sprintf("Macro '%s' must be in SCREAMING_SNAKE_CASE", macroName),
-					})
-				}
+		directive, ok := parseDirective(line)
+		if ok && directive.Name == "define" {
+			macroName := macroName(directive.Args)
+			if macroName != "" && !isScreamingSnakeCase(macroName) {
+				violations = append(violations, atWord(Violation{
+					Rule:      "C-F2",
+					MessageID: "invalid-macro-name",
+					Args:      []any{macroName},
+					Line:      i + 1,
+					Severity:  SeverityMajor,
+				}, analysis.Lines, macroName))
 			}
 		}
 	}
@@ -488,13 +892,13 @@ func checkFunctionLength(analysis *FileAnalysis, filename string, lineNum int) [
 	for _, fn := range analysis.Functions {
 		length := fn.EndLine - fn.StartLine + 1
 		if length > 25 {
-			violations = append(violations, Violation{
-				Rule:        "C-F3",
-				Message:     "Function too long",
-				Line:        fn.StartLine,
-				Severity:    "major",
-				Description: fmt.Sprintf("Function '%s' has %d lines (max 25)", fn.Name, length),
-			})
+			violations = append(violations, atWord(Violation{
+				Rule:      "C-F3",
+				MessageID: "function-too-long",
+				Args:      []any{fn.Name, length},
+				Line:      fn.StartLine,
+				Severity:  SeverityMajor,
+			}, analysis.Lines, fn.Name))
 		}
 	}
 	return violations
@@ -505,13 +909,12 @@ func checkCommentFormat(analysis *FileAnalysis, filename string, lineNum int) []
 	var violations []Violation
 	for i, line := range analysis.Lines {
 		if strings.Contains(line, "//") {
-			violations = append(violations, Violation{
-				Rule:        "C-C1",
-				Message:     "Invalid comment format",
-				Line:        i + 1,
-				Severity:    "minor",
-				Description: "Use /* */ comments only, not // comments",
-			})
+			violations = append(violations, atWord(Violation{
+				Rule:      "C-C1",
+				MessageID: "invalid-comment-format",
+				Line:      i + 1,
+				Severity:  SeverityMinor,
+			}, analysis.Lines, "//"))
 		}
 	}
 	return violations
@@ -531,13 +934,13 @@ func checkFunctionParameters(analysis *FileAnalysis, filename string, lineNum in
 	var violations []Violation
 	for _, fn := range analysis.Functions {
 		if fn.ParamCount > 4 {
-			violations = append(violations, Violation{
-				Rule:        "C-F4",
-				Message:     "Too many parameters",
-				Line:        fn.StartLine,
-				Severity:    "major",
-				Description: fmt.Sprintf("Function '%s' has %d parameters (max 4)", fn.Name, fn.ParamCount),
-			})
+			violations = append(violations, atWord(Violation{
+				Rule:      "C-F4",
+				MessageID: "too-many-parameters",
+				Args:      []any{fn.Name, fn.ParamCount},
+				Line:      fn.StartLine,
+				Severity:  SeverityMajor,
+			}, analysis.Lines, fn.Name))
 		}
 	}
 	return violations
@@ -548,19 +951,150 @@ func checkForLoopDeclaration(analysis *FileAnalysis, filename string, lineNum in
 	for i, line := range analysis.Lines {
 		trimmed := strings.TrimSpace(line)
 		if strings.Contains(trimmed, "for") && strings.Contains(trimmed, "int ") {
-			violations = append(violations, Violation{
-				Rule:        "C-L5",
-				Message:     "Variable declaration in for loop",
-				Line:        i + 1,
-				Severity:    "major",
-				Description: "Do not declare variables in for loop initialization",
-			})
+			violations = append(violations, atWord(Violation{
+				Rule:      "C-L5",
+				MessageID: "for-loop-declaration",
+				Line:      i + 1,
+				Severity:  SeverityMajor,
+			}, analysis.Lines, "for"))
 		}
 	}
 	return violations
 }
 
 // Helper functions
+
+// displayWidth counts the columns a line takes on screen: one per rune,
+// tabs advancing to the next multiple of tabWidth.
+func displayWidth(line string, tabWidth int) int {
+	columns := 0
+	for _, r := range line {
+		if r == '\t' {
+			columns += tabWidth - columns%tabWidth
+		} else if r != '\r' {
+			columns++
+		}
+	}
+	return columns
+}
+
+// columnAt returns the 1-based byte column of the first rune drawn past
+// the given display width.
+func columnAt(line string, width, tabWidth int) int {
+	columns := 0
+	for i, r := range line {
+		if r == '\t' {
+			columns += tabWidth - columns%tabWidth
+		} else {
+			columns++
+		}
+		if columns > width {
+			return i + 1
+		}
+	}
+	return len(line) + 1
+}
+
+// atToken places a violation on a token of the source.
+func atToken(v Violation, tok Token) Violation {
+	return atTokens(v, tok, tok)
+}
+
+// atTokens places a violation on the tokens from first to last.
+func atTokens(v Violation, first, last Token) Violation {
+	v.Line, v.Column = first.Line, first.Col
+	v.EndLine, v.EndColumn = last.EndPosition()
+	return v
+}
+
+// atColumns places a violation on the columns [column, endColumn) of a line.
+func atColumns(v Violation, line, column, endColumn int) Violation {
+	v.Line, v.Column = line, column
+	v.EndLine, v.EndColumn = line, endColumn
+	return v
+}
+
+// atOffsets places a violation on the source bytes [start, end).
+func atOffsets(v Violation, source string, start, end int) Violation {
+	v.Line = strings.Count(source[:start], "\n") + 1
+	v.Column = start - strings.LastIndexByte(source[:start], '\n')
+	v.EndLine = strings.Count(source[:end], "\n") + 1
+	v.EndColumn = end - strings.LastIndexByte(source[:end], '\n')
+	return v
+}
+
+// atWord places a violation on the first occurrence of word in its line,
+// or on the whole line when the word cannot be found.
+func atWord(v Violation, lines []string, word string) Violation {
+	if v.Line < 1 || v.Line > len(lines) {
+		return v
+	}
+	column := strings.Index(lines[v.Line-1], word)
+	if column < 0 {
+		return atLine(v, lines)
+	}
+	return atColumns(v, v.Line, column+1, column+1+len(word))
+}
+
+// atLine places a violation on the text of its line, indentation and
+// trailing blanks excluded.
+func atLine(v Violation, lines []string) Violation {
+	if v.Line < 1 || v.Line > len(lines) {
+		return v
+	}
+	line := strings.TrimRight(lines[v.Line-1], " \t\r")
+	column := len(line) - len(strings.TrimLeft(line, " \t")) + 1
+	return atColumns(v, v.Line, column, len(line)+1)
+}
+
+// atDisplayColumns turns the byte columns the rules work with into the
+// columns the violation is displayed at, as measured by displayWidth.
+func atDisplayColumns(v Violation, lines []string, tabWidth int) Violation {
+	if v.Column > 0 && v.Line >= 1 && v.Line <= len(lines) {
+		v.Column = displayColumn(lines[v.Line-1], v.Column, tabWidth)
+	}
+	if v.EndColumn > 0 && v.EndLine >= 1 && v.EndLine <= len(lines) {
+		v.EndColumn = displayColumn(lines[v.EndLine-1], v.EndColumn, tabWidth)
+	}
+	return v
+}
+
+// displayColumn returns the display column of the byte at the 1-based
+// column of a line. Columns past the end of the line are kept past it.
+func displayColumn(line string, column, tabWidth int) int {
+	if column > len(line) {
+		return displayWidth(line, tabWidth) + column - len(line)
+	}
+	return displayWidth(line[:column-1], tabWidth) + 1
+}
+
+// expandTabs replaces the tabs of the lines by the spaces they span.
+func expandTabs(lines []string, tabWidth int) []string {
+	expanded := make([]string, len(lines))
+	for i, line := range lines {
+		if !strings.Contains(line, "\t") {
+			expanded[i] = line
+			continue
+		}
+		var b strings.Builder
+		columns := 0
+		for _, r := range line {
+			if r == '\t' {
+				width := tabWidth - columns%tabWidth
+				b.WriteString(strings.Repeat(" ", width))
+				columns += width
+				continue
+			}
+			b.WriteRune(r)
+			if r != '\r' {
+				columns++
+			}
+		}
+		expanded[i] = b.String()
+	}
+	return expanded
+}
+
 func isSnakeCase(s string) bool {
 	if s == "" {
 		return false
@@ -654,25 +1188,33 @@ func extractFunctions(lines []string) []FunctionInfo {
 
 func printReport(report *Report, verbose bool) {
 	// Print header
-	fmt.Println(ColorBold + "╔══════════════════════════════════════════════════════════════════════════════╗" + ColorReset)
-	fmt.Println(ColorBold + "║                         EPICSTYLE - RAPPORT D'ANALYSE                        ║" + ColorReset)
-	fmt.Println(ColorBold + "╚══════════════════════════════════════════════════════════════════════════════╝" + ColorReset)
+	fmt.Println(boxBorder(true))
+	fmt.Println(boxLine(ColorBold + tr("report.title") + ColorReset))
+	fmt.Println(boxBorder(false))
 	fmt.Println()
 
 	// Print summary
-	fmt.Printf("📊 %sRÉSUMÉ GLOBAL%s\n", ColorBold, ColorReset)
-	fmt.Printf("   • Fichiers analysés: %d\n", report.TotalFiles)
-	fmt.Printf("   • Lignes de code: %d\n", report.TotalLines)
-	fmt.Printf("   • Violations totales: %d\n", report.TotalViolations)
-	fmt.Printf("   • Fichiers propres: %d/%d\n", report.CleanFiles, report.TotalFiles)
+	fmt.Printf("%s%s%s%s\n", symbols.Summary, ColorBold, tr("report.summary"), ColorReset)
+	fmt.Printf("   %s %s: %d\n", symbols.Bullet, tr("report.files"), report.TotalFiles)
+	fmt.Printf("   %s %s: %d\n", symbols.Bullet, tr("report.lines"), report.TotalLines)
+	fmt.Printf("   %s %s: %d\n", symbols.Bullet, tr("report.violations"), report.TotalViolations)
+	fmt.Printf("   %s %s: %d/%d\n", symbols.Bullet, tr("report.clean-files"), report.CleanFiles, report.TotalFiles)
+	if len(report.Errors) > 0 {
+		fmt.Printf("   %s %s: %s%d%s\n", symbols.Bullet, tr("report.error-files"), ColorRed, len(report.Errors), ColorReset)
+	}
 	
 	cleanPercent := 0.0
 	if report.TotalFiles > 0 {
 		cleanPercent = float64(report.CleanFiles) / float64(report.TotalFiles) * 100
 	}
-	fmt.Printf("   • Propreté: %.1f%% %s\n", cleanPercent, getProgressBar(cleanPercent))
+	fmt.Printf("   %s %s: %.1f%% %s\n", symbols.Bullet, tr("report.cleanliness"), cleanPercent, getProgressBar(cleanPercent))
 	fmt.Println()
 
+	model, err := scoringModel(report.Scoring)
+	if err != nil {
+		model = scoringModels[DefaultScoring]
+	}
+
 	// Sort files by score (descending)
 	sort.Slice(report.Files, func(i, j int) bool {
 		return report.Files[i].Score > report.Files[j].Score
@@ -681,49 +1223,121 @@ func printReport(report *Report, verbose bool) {
 	// Print file results
 	for _, file := range report.Files {
 		if len(file.Violations) == 0 {
-			fmt.Printf("%s✅ %s%s (%.1f%% - %d lignes)\n", 
-				ColorGreen, file.Filename, ColorReset, file.Score, file.LineCount)
+			fmt.Printf("%s%s%s%s (%s - %s)\n",
+				ColorGreen, symbols.Clean, file.Filename, ColorReset, model.Format(file.Score), tr("report.file-lines", file.LineCount))
 		} else {
-			fmt.Printf("%s❌ %s%s (%.1f%% - %d lignes - %d violations)\n", 
-				ColorRed, file.Filename, ColorReset, file.Score, file.LineCount, len(file.Violations))
+			fmt.Printf("%s%s%s%s (%s - %s - %s)\n",
+				ColorRed, symbols.Dirty, file.Filename, ColorReset, model.Format(file.Score), tr("report.file-lines", file.LineCount),
+				tr("report.file-violations", len(file.Violations)))
 		}
 		
 		if verbose && len(file.Violations) > 0 {
 			for _, v := range file.Violations {
 				severity := ColorYellow + "MINOR" + ColorReset
-				if v.Severity == "major" {
+				switch v.Severity {
+				case SeverityMajor:
 					severity = ColorRed + "MAJOR" + ColorReset
+				case SeverityInfo:
+					severity = ColorBlue + "INFO" + ColorReset
+				}
+				if v.Column > 0 {
+					fmt.Printf("    [%s] %s %d:%d: %s - %s\n", severity, tr("report.line"), v.Line, v.Column, v.Rule, v.Message)
+				} else {
+					fmt.Printf("    [%s] %s %d: %s - %s\n", severity, tr("report.line"), v.Line, v.Rule, v.Message)
 				}
-				fmt.Printf("    [%s] Line %d: %s - %s\n", severity, v.Line, v.Rule, v.Message)
 				if v.Description != "" {
 					fmt.Printf("         %s\n", v.Description)
 				}
+				printExcerpt(file.Lines, v)
 			}
 		}
 	}
 	
+	if len(report.Errors) > 0 {
+		fmt.Println()
+		fmt.Printf("%s%s%s%s\n", symbols.Warning, ColorBold, tr("report.errors"), ColorReset)
+		for _, fileErr := range report.Errors {
+			fmt.Printf("%s%s%s%s: %s\n", ColorRed, symbols.Error, fileErr.Filename, ColorReset, fileErr.Error)
+		}
+	}
+
 	fmt.Println()
 
 	// Print final score
 	scoreColor := ColorRed
-	scoreMessage := "❌ ÉCHEC! Beaucoup de travail nécessaire."
-	if report.TotalScore >= 90 {
+	scoreMessage := symbols.Dirty + tr("report.rank-3")
+	switch model.Rank(report.TotalScore) {
+	case 0:
 		scoreColor = ColorGreen
-		scoreMessage = "🎉 EXCELLENT! Code très propre."
-	} else if report.TotalScore >= 75 {
+		scoreMessage = symbols.Excellent + tr("report.rank-0")
+	case 1:
 		scoreColor = ColorYellow
-		scoreMessage = "🎉 TRÈS BIEN! Quelques petits détails à corriger."
-	} else if report.TotalScore >= 50 {
+		scoreMessage = symbols.Excellent + tr("report.rank-1")
+	case 2:
 		scoreColor = ColorYellow
-		scoreMessage = "⚠️  CORRECT! Plusieurs améliorations nécessaires."
+		scoreMessage = symbols.Warning + tr("report.rank-2")
+	}
+
+	fmt.Println(boxBorder(true))
+	fmt.Println(boxLine(fmt.Sprintf("%s%s: %s%s", scoreColor, tr("report.score"), model.Format(report.TotalScore), ColorReset)))
+	if model.Percent {
+		fmt.Println(boxLine(fmt.Sprintf("%s %.1f%%", getProgressBar(report.TotalScore), report.TotalScore)))
+	} else {
+		fmt.Println(boxLine(fmt.Sprintf("%s: %s", tr("report.scoring"), model.Name)))
+	}
+	fmt.Println(boxLine(scoreMessage))
+	fmt.Println(boxBorder(false))
+}
+
+// printTextReport prints one "file:line:column: rule message" line per
+// violation, the format of compilers understood by editors' quickfix lists.
+func printTextReport(report *Report) {
+	for _, file := range report.Files {
+		filename := file.Path
+		if filename == "" {
+			filename = file.Filename
+		}
+		sortViolations(file.Violations)
+		for _, v := range file.Violations {
+			location := filename
+			if v.Line > 0 {
+				location += fmt.Sprintf(":%d", v.Line)
+				if v.Column > 0 {
+					location += fmt.Sprintf(":%d", v.Column)
+				}
+			}
+			fmt.Printf("%s: %s %s\n", location, v.Rule, v.Message)
+		}
+	}
+	for _, fileErr := range report.Errors {
+		fmt.Printf("%s: error: %s\n", fileErr.Filename, fileErr.Error)
+	}
+}
+
+// printExcerpt prints the source line of a violation with a caret
+// underline below its span, like compiler diagnostics.
+func printExcerpt(lines []string, v Violation) {
+	if v.Line < 1 || v.Line > len(lines) || v.Column < 1 {
+		return
+	}
+	line := []rune(strings.TrimRight(lines[v.Line-1], "\r"))
+	start := v.Column - 1
+	end := v.EndColumn - 1
+	if v.EndLine > v.Line || end > len(line) {
+		end = len(line)
+	}
+	if start > len(line) {
+		start = len(line)
+	}
+	carets := end - start
+	if carets < 1 {
+		carets = 1
 	}
 
-	fmt.Println(ColorBold + "╔══════════════════════════════════════════════════════════════════════════════╗" + ColorReset)
-	fmt.Printf("║%s                             SCORE GLOBAL: %.1f%%                              %s ║\n", 
-		scoreColor, report.TotalScore, ColorReset)
-	fmt.Printf("║           %s%.1f%%           ║\n", getProgressBar(report.TotalScore), report.TotalScore)
-	fmt.Printf("║                   %s                  ║\n", scoreMessage)
-	fmt.Println(ColorBold + "╚══════════════════════════════════════════════════════════════════════════════╝" + ColorReset)
+	gutter := fmt.Sprintf("%d", v.Line)
+	fmt.Printf("         %s | %s\n", gutter, string(line))
+	fmt.Printf("         %s | %s%s%s%s\n", strings.Repeat(" ", len(gutter)),
+		strings.Repeat(" ", start), ColorRed, strings.Repeat("^", carets), ColorReset)
 }
 
 func getProgressBar(percentage float64) string {
@@ -731,6 +1345,6 @@ func getProgressBar(percentage float64) string {
 	filled := int(percentage / 100 * float64(barLength))
 	empty := barLength - filled
 	
-	bar := ColorGreen + strings.Repeat("█", filled) + ColorReset + strings.Repeat("░", empty)
+	bar := ColorGreen + strings.Repeat(symbols.Filled, filled) + ColorReset + strings.Repeat(symbols.Empty, empty)
 	return "[" + bar + "]"
 }
\ No newline at end of file
@@ -0,0 +1,157 @@
+// main_test.go
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDisplayColumn(t *testing.T) {
+	tests := []struct {
+		line     string
+		column   int
+		tabWidth int
+		want     int
+	}{
+		{"int a;", 5, 8, 5},
+		{"\tint a;", 2, 8, 9},
+		{"\tint a;", 2, 4, 5},
+		{"  \tx", 4, 8, 9},
+		{"\"é\" + 42", 7, 8, 6},
+		{"// ☃\tx", 8, 8, 9},
+		{"abc", 4, 8, 4},
+		{"\tab", 4, 8, 11},
+		{"\tab", 5, 8, 12},
+	}
+	for _, tt := range tests {
+		if got := displayColumn(tt.line, tt.column, tt.tabWidth); got != tt.want {
+			t.Errorf("displayColumn(%q, %d, %d) = %d, want %d", tt.line, tt.column, tt.tabWidth, got, tt.want)
+		}
+	}
+}
+
+func TestExpandTabs(t *testing.T) {
+	got := expandTabs([]string{"\tx", "ab\tc", "é\t", "none"}, 4)
+	want := []string{"    x", "ab  c", "é   ", "none"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expandTabs() = %q, want %q", got, want)
+	}
+}
+
+// captureStdout returns what f prints on the standard output.
+func captureStdout(t *testing.T, f func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+	f()
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestViolationDisplayColumns(t *testing.T) {
+	source := "int main(void)\n{\n\tprintf(\"é\\n\", 42);\n\treturn 0;\n}\n"
+	config := DefaultConfig()
+	config.TabWidth = 4
+	analyzer := NewAnalyzer(2, config)
+	analysis := analyzer.newFileAnalysis("test.c", []byte(source))
+	result, err := analyzer.analyzeFile(analysis)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var magic *Violation
+	for i, v := range result.Violations {
+		if v.Rule == "C-G4" {
+			magic = &result.Violations[i]
+		}
+	}
+	if magic == nil {
+		t.Fatalf("no C-G4 violation in %+v", result.Violations)
+	}
+	// The tab spans 4 columns and 'é' a single one, though 2 bytes
+	if magic.Line != 3 || magic.Column != 19 || magic.EndColumn != 21 {
+		t.Errorf("42 at %d:%d-%d, want 3:19-21", magic.Line, magic.Column, magic.EndColumn)
+	}
+
+	out := captureStdout(t, func() { printExcerpt(result.Lines, *magic) })
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("excerpt %q, want 2 lines", out)
+	}
+	source3 := strings.TrimPrefix(lines[0], "         3 | ")
+	marker := strings.TrimPrefix(lines[1], "           | ")
+	if source3 != "    printf(\"é\\n\", 42);" {
+		t.Errorf("excerpt line %q", source3)
+	}
+	if want := strings.Repeat(" ", 18) + ColorRed + "^^" + ColorReset; marker != want {
+		t.Errorf("marker %q, want %q", marker, want)
+	}
+}
+
+func TestRuleInternalError(t *testing.T) {
+	dir := t.TempDir()
+	for name, source := range map[string]string{"bad.c": "int foo(", "good.c": "int foo(void);\n"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(source), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	analyzer := NewAnalyzer(2, DefaultConfig())
+	analyzer.rules["C-X0"] = Rule{
+		Code: "C-X0", Severity: SeverityMajor, Level: 1,
+		Check: func(analysis *FileAnalysis, filename string, lineNum int) []Violation {
+			if strings.HasSuffix(filename, "bad.c") {
+				_ = analysis.Code[len(analysis.Code)]
+			}
+			return nil
+		},
+	}
+
+	report := analyzer.AnalyzePaths([]string{dir})
+	if len(report.Files) != 1 || report.Files[0].Filename != filepath.Join(dir, "good.c") {
+		t.Errorf("got files %+v, want good.c only", report.Files)
+	}
+	if len(report.Errors) != 1 || !report.Errors[0].Internal ||
+		!strings.HasPrefix(report.Errors[0].Error, "internal error in rule C-X0: ") {
+		t.Errorf("got errors %+v, want an internal error of C-X0 for bad.c", report.Errors)
+	}
+	if code := exitCode(report, FailPolicy{FailOn: SeverityMinor}); code != ExitInternal {
+		t.Errorf("exitCode() = %d, want %d", code, ExitInternal)
+	}
+}
+
+func TestPrintTextReport(t *testing.T) {
+	report := &Report{
+		Files: []FileResult{
+			{Filename: "src/main.c", Violations: []Violation{
+				{Rule: "C-L1", Message: "Line too long", Line: 12, Column: 81},
+				{Rule: "C-O1", Message: "Forbidden file"},
+				{Rule: "C-G4", Message: "Magic number", Line: 3, Column: 19},
+				{Rule: "C-F3", Message: "Function too long", Line: 3},
+			}},
+			{Filename: "clean.c"},
+		},
+		Errors: []FileError{{Filename: "bad.c", Error: "not valid UTF-8"}},
+	}
+	out := captureStdout(t, func() { printTextReport(report) })
+	want := "src/main.c: C-O1 Forbidden file\n" +
+		"src/main.c:3: C-F3 Function too long\n" +
+		"src/main.c:3:19: C-G4 Magic number\n" +
+		"src/main.c:12:81: C-L1 Line too long\n" +
+		"bad.c: error: not valid UTF-8\n"
+	if out != want {
+		t.Errorf("text report:\n%s\nwant:\n%s", out, want)
+	}
+}
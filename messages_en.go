@@ -0,0 +1,254 @@
+// messages_en.go
+package main
+
+var catalogueEN = Catalogue{
+	// Terminal report
+	"report.title":           "EPICSTYLE - ANALYSIS REPORT",
+	"report.summary":         "GLOBAL SUMMARY",
+	"report.files":           "Files analyzed",
+	"report.lines":           "Lines of code",
+	"report.violations":      "Total violations",
+	"report.clean-files":     "Clean files",
+	"report.error-files":     "Files in error",
+	"report.cleanliness":     "Cleanliness",
+	"report.file-lines":      "%d lines",
+	"report.file-violations": "%d violations",
+	"report.line":            "Line",
+	"report.errors":          "FILES NOT ANALYZED",
+	"report.score":           "GLOBAL SCORE",
+	"report.scoring":         "Scoring",
+	"report.rank-0":          "EXCELLENT! Very clean code.",
+	"report.rank-1":          "VERY GOOD! A few details to fix.",
+	"report.rank-2":          "FAIR! Several improvements needed.",
+	"report.rank-3":          "FAILED! A lot of work needed.",
+
+	// rules and explain commands
+	"rules.header":     "CODE\tLEVEL\tSEVERITY\tSTATE\tNAME",
+	"rules.enabled":    "enabled",
+	"rules.disabled":   "disabled",
+	"rules.optional":   "optional",
+	"rules.level":      "level %d",
+	"explain.summary":  "%s (%s, level %d)",
+	"explain.bad":      "Incorrect:",
+	"explain.good":     "Correct:",
+	"tui.title":        " EPICSTYLE  score %s  %d files  %d violations  filter: %s  order: %s",
+	"tui.all":          "all",
+	"tui.help":         " ↑↓ move  ←→ pane  s severity  r rule  c clear  o order  e edit  q quit",
+	"tui.worst-first":  "worst first",
+	"tui.by-name":      "name",
+	"tui.rule-prompt":  " rule: ",
+	"tui.no-violation": " No violation",
+
+	// Rules
+	"rule.C-L1":              "Line Length",
+	"rule.C-L1.description":  "Line too long (80 chars max)",
+	"rule.C-L2":              "Empty Lines",
+	"rule.C-L2.description":  "Forbidden empty lines",
+	"rule.C-L3":              "Indentation",
+	"rule.C-L3.description":  "TAB indentation only",
+	"rule.C-L4":              "Variable Declaration",
+	"rule.C-L4.description":  "One variable per line",
+	"rule.C-L5":              "For Loop Declaration",
+	"rule.C-L5.description":  "No declaration in for loops",
+	"rule.C-L6":              "Spacing",
+	"rule.C-L6.description":  "Spaces around operators, after keywords and commas",
+	"rule.C-L7":              "Brace Placement",
+	"rule.C-L7.description":  "Curly brackets placement",
+	"rule.C-V1":              "Variable Position",
+	"rule.C-V1.description":  "Variables at function start",
+	"rule.C-V2":              "Typedef Name",
+	"rule.C-V2.description":  "Typedef in snake_case ending with _t",
+	"rule.C-V3":              "Type Tag Name",
+	"rule.C-V3.description":  "Struct, union and enum tags in snake_case",
+	"rule.C-V4":              "Enum Constant Name",
+	"rule.C-V4.description":  "Enum constants in SCREAMING_SNAKE_CASE",
+	"rule.C-V5":              "Global Constant Name",
+	"rule.C-V5.description":  "Global constants in SCREAMING_SNAKE_CASE",
+	"rule.C-V6":              "Local Variable Name",
+	"rule.C-V6.description":  "Local variables in snake_case",
+	"rule.C-V7":              "Parameter Name",
+	"rule.C-V7.description":  "Parameters in snake_case",
+	"rule.C-V8":              "Pointer Declaration",
+	"rule.C-V8.description":  "Asterisk attached to the declared name",
+	"rule.C-O1":              "Filename",
+	"rule.C-O1.description":  "Filename in snake_case",
+	"rule.C-O2":              "Function Count",
+	"rule.C-O2.description":  "Max 3 functions per file",
+	"rule.C-O3":              "Duplicate Code",
+	"rule.C-O3.description":  "No duplicated code across files",
+	"rule.C-O4":              "Delivery Files",
+	"rule.C-O4.description":  "No build artifacts, binaries or temporary files",
+	"rule.C-F1":              "Function Name",
+	"rule.C-F1.description":  "Function name in snake_case",
+	"rule.C-F2":              "Macro Name",
+	"rule.C-F2.description":  "Macro in SCREAMING_SNAKE_CASE",
+	"rule.C-F3":              "Function Length",
+	"rule.C-F3.description":  "Function max 25 lines",
+	"rule.C-F4":              "Function Parameters",
+	"rule.C-F4.description":  "Max 4 parameters",
+	"rule.C-F5":              "Empty Parameter List",
+	"rule.C-F5.description":  "Empty parameter list must be (void)",
+	"rule.C-F6":              "Structure By Value",
+	"rule.C-F6.description":  "Structures passed by pointer",
+	"rule.C-F7":              "Comment In Function",
+	"rule.C-F7.description":  "No comments inside function bodies",
+	"rule.C-F8":              "Nested Function",
+	"rule.C-F8.description":  "No nested function definitions",
+	"rule.C-F9":              "Unused Static Function",
+	"rule.C-F9.description":  "Static functions must be used",
+	"rule.C-F10":             "Function Complexity",
+	"rule.C-F10.description": "Cyclomatic complexity under the threshold",
+	"rule.C-C1":              "Comment Format",
+	"rule.C-C1.description":  "/* */ comments only",
+	"rule.C-C2":              "Function Comment",
+	"rule.C-C2.description":  "Function comment required",
+	"rule.C-G1":              "Global Variables",
+	"rule.C-G1.description":  "No non-const globals",
+	"rule.C-G2":              "Line Jumps",
+	"rule.C-G2.description":  "Blank lines between functions and after declarations",
+	"rule.C-G3":              "Preprocessor Directives",
+	"rule.C-G3.description":  "Directive indentation and include placement",
+	"rule.C-G4":              "Magic Numbers",
+	"rule.C-G4.description":  "No hard-coded numeric literals",
+	"rule.C-G5":              "Forbidden Functions",
+	"rule.C-G5.description":  "Only allowed functions may be called",
+	"rule.C-G6":              "Forbidden Headers",
+	"rule.C-G6.description":  "Only allowed headers may be included",
+
+	// Violations: the message, then the format of the description
+	"line-too-long":                                    "Line too long",
+	"line-too-long.description":                        "Line spans %d columns (max 80)",
+	"empty-line-at-start":                              "Empty line at beginning of file",
+	"empty-line-at-start.description":                  "File should not start with empty line",
+	"empty-line-at-end":                                "Empty line at end of file",
+	"empty-line-at-end.description":                    "File should not end with empty line",
+	"consecutive-empty-lines":                          "Consecutive empty lines",
+	"consecutive-empty-lines.description":              "Multiple consecutive empty lines are forbidden",
+	"space-indentation":                                "Space indentation",
+	"space-indentation.description":                    "Use TAB for indentation, not spaces",
+	"multiple-variable-declaration":                    "Multiple variable declaration",
+	"multiple-variable-declaration.description":        "Declare only one variable per line",
+	"for-loop-declaration":                             "Variable declaration in for loop",
+	"for-loop-declaration.description":                 "Do not declare variables in for loop initialization",
+	"missing-space-after-keyword":                      "Invalid spacing",
+	"missing-space-after-keyword.description":          "Missing space after keyword '%s'",
+	"missing-space-after-comma":                        "Invalid spacing",
+	"missing-space-after-comma.description":            "Missing space after ','",
+	"space-before-semicolon":                           "Invalid spacing",
+	"space-before-semicolon.description":               "Unexpected space before ';'",
+	"space-after-parenthesis":                          "Invalid spacing",
+	"space-after-parenthesis.description":              "Unexpected space after '('",
+	"space-before-parenthesis":                         "Invalid spacing",
+	"space-before-parenthesis.description":             "Unexpected space before ')'",
+	"missing-space-around-operator":                    "Invalid spacing",
+	"missing-space-around-operator.description":        "Missing space around operator '%s'",
+	"misplaced-else":                                   "Misplaced 'else'",
+	"misplaced-else.description":                       "'else' must be on the same line as the closing brace: '} else'",
+	"misplaced-while":                                  "Misplaced 'while'",
+	"misplaced-while.description":                      "'while' must be on the same line as the closing brace: '} while'",
+	"misplaced-function-brace":                         "Misplaced function brace",
+	"misplaced-function-brace.description":             "Function opening brace must be alone on its own line",
+	"misplaced-control-brace":                          "Misplaced control brace",
+	"misplaced-control-brace.description":              "Control structure opening brace must be at the end of the line",
+	"code-after-control-brace":                         "Misplaced control brace",
+	"code-after-control-brace.description":             "Nothing may follow a control structure opening brace on the same line",
+	"misplaced-brace":                                  "Misplaced brace",
+	"misplaced-brace.description":                      "Opening brace must be on the same line as the declaration",
+	"misplaced-closing-brace":                          "Misplaced closing brace",
+	"misplaced-closing-brace.description":              "Closing brace must be on its own line",
+	"code-after-function-brace":                        "Misplaced closing brace",
+	"code-after-function-brace.description":            "Nothing may follow a function closing brace on the same line",
+	"invalid-typedef-name":                             "Invalid typedef name",
+	"invalid-typedef-name.description":                 "Typedef '%s' must be in snake_case ending with '_t'",
+	"invalid-typedef-name-pattern":                     "Invalid typedef name",
+	"invalid-typedef-name-pattern.description":         "Typedef '%s' must match '%s'",
+	"invalid-tag-name":                                 "Invalid type tag name",
+	"invalid-tag-name.description":                     "Type tag '%s' must be in snake_case",
+	"invalid-tag-name-pattern":                         "Invalid type tag name",
+	"invalid-tag-name-pattern.description":             "Type tag '%s' must match '%s'",
+	"invalid-enum-constant-name":                       "Invalid enum constant name",
+	"invalid-enum-constant-name.description":           "Enum constant '%s' must be in SCREAMING_SNAKE_CASE",
+	"invalid-enum-constant-name-pattern":               "Invalid enum constant name",
+	"invalid-enum-constant-name-pattern.description":   "Enum constant '%s' must match '%s'",
+	"invalid-global-constant-name":                     "Invalid global constant name",
+	"invalid-global-constant-name.description":         "Global constant '%s' must be in SCREAMING_SNAKE_CASE",
+	"invalid-global-constant-name-pattern":             "Invalid global constant name",
+	"invalid-global-constant-name-pattern.description": "Global constant '%s' must match '%s'",
+	"invalid-local-variable-name":                      "Invalid local variable name",
+	"invalid-local-variable-name.description":          "Local variable '%s' must be in snake_case",
+	"invalid-local-variable-name-pattern":              "Invalid local variable name",
+	"invalid-local-variable-name-pattern.description":  "Local variable '%s' must match '%s'",
+	"invalid-parameter-name":                           "Invalid parameter name",
+	"invalid-parameter-name.description":               "Parameter '%s' must be in snake_case",
+	"invalid-parameter-name-pattern":                   "Invalid parameter name",
+	"invalid-parameter-name-pattern.description":       "Parameter '%s' must match '%s'",
+	"misplaced-pointer-asterisk":                       "Misplaced pointer asterisk",
+	"misplaced-pointer-asterisk.description":           "Attach the asterisk to the name: '%s'",
+	"invalid-filename":                                 "Invalid filename format",
+	"invalid-filename.description":                     "Filename must be in snake_case",
+	"too-many-functions":                               "Too many functions",
+	"too-many-functions.description":                   "File contains %d functions (max 3 excluding main)",
+	"duplicated-code":                                  "Duplicated code",
+	"duplicated-code.description":                      "%d tokens of '%s' duplicate %s:%d in '%s'",
+	"forbidden-delivery-file":                          "Forbidden delivery file",
+	"forbidden-delivery-file.description":              "'%s' (%s) must not be delivered",
+	"invalid-function-name":                            "Invalid function name",
+	"invalid-function-name.description":                "Function '%s' must be in snake_case",
+	"invalid-macro-name":                               "Invalid macro name",
+	"invalid-macro-name.description":                   "Macro '%s' must be in SCREAMING_SNAKE_CASE",
+	"function-too-long":                                "Function too long",
+	"function-too-long.description":                    "Function '%s' has %d lines (max 25)",
+	"too-many-parameters":                              "Too many parameters",
+	"too-many-parameters.description":                  "Function '%s' has %d parameters (max 4)",
+	"empty-parameter-list":                             "Empty parameter list",
+	"empty-parameter-list.description":                 "Function '%[1]s' takes no parameter and must be declared as '%[1]s(void)'",
+	"structure-by-value":                               "Structure passed by value",
+	"structure-by-value.description":                   "Parameter '%s' of type '%s' must be passed by pointer",
+	"comment-in-function":                              "Comment inside function",
+	"comment-in-function.description":                  "Comments are forbidden inside the body of '%s', document it above its definition",
+	"nested-function":                                  "Nested function definition",
+	"nested-function.description":                      "Function '%s' is defined inside another function",
+	"unused-static-function":                           "Unused static function",
+	"unused-static-function.description":               "Static function '%s' is never used in this file",
+	"function-too-complex":                             "Function too complex",
+	"function-too-complex.description":                 "Function '%s' has a cyclomatic complexity of %d (max %d)",
+	"invalid-comment-format":                           "Invalid comment format",
+	"invalid-comment-format.description":               "Use /* */ comments only, not // comments",
+	"blank-line-after-comment":                         "Blank line after header comment",
+	"blank-line-after-comment.description":             "No blank line is allowed between the comment of '%s' and its definition",
+	"function-separation":                              "Invalid separation between functions",
+	"function-separation.description":                  "Exactly one blank line must precede '%s' (found %d)",
+	"missing-blank-after-declarations":                 "Missing blank line after declarations",
+	"missing-blank-after-declarations.description":     "Separate the declarations of '%s' from its instructions with one blank line",
+	"blank-line-in-function":                           "Blank line inside function",
+	"blank-line-in-function.description":               "Only the line after the declarations of '%s' may be blank",
+	"unbalanced-directive":                             "Unbalanced conditional directive",
+	"unbalanced-directive.description":                 "'#%s' without matching '#if'",
+	"misaligned-directive":                             "Misaligned conditional directive",
+	"misaligned-directive.description":                 "'#%s' must be aligned with its '#if'",
+	"misplaced-include":                                "Misplaced include",
+	"misplaced-include.description":                    "#include directives must be at the top of the file, before any code",
+	"included-source-file":                             "Included source file",
+	"included-source-file.description":                 "'%s' is a source file and must not be included",
+	"indented-directive":                               "Indented directive",
+	"indented-directive.description":                   "'#%s' outside of any conditional block must not be indented",
+	"unindented-directive":                             "Unindented directive",
+	"unindented-directive.description":                 "'#%[1]s' must be indented inside its conditional block, as in '#    %[1]s'",
+	"magic-number":                                     "Magic number",
+	"magic-number.description":                         "Magic number %s in '%s', use a named constant",
+	"forbidden-function":                               "Forbidden function",
+	"forbidden-function.description":                   "Function '%s' is not allowed in this project",
+	"forbidden-header":                                 "Forbidden header",
+	"forbidden-header.description":                     "Header '%s' is not allowed in this project",
+
+	// Kinds of files that must not be delivered
+	"term.object-file":     "object file",
+	"term.static-library":  "static library",
+	"term.shared-library":  "shared library",
+	"term.coverage-data":   "coverage data",
+	"term.coverage-notes":  "coverage notes",
+	"term.core-dump":       "valgrind core dump",
+	"term.editor-backup":   "editor backup",
+	"term.editor-autosave": "editor autosave",
+	"term.compiled-binary": "compiled binary",
+}
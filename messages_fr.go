@@ -0,0 +1,254 @@
+// messages_fr.go
+package main
+
+var catalogueFR = Catalogue{
+	// Terminal report
+	"report.title":           "EPICSTYLE - RAPPORT D'ANALYSE",
+	"report.summary":         "RÉSUMÉ GLOBAL",
+	"report.files":           "Fichiers analysés",
+	"report.lines":           "Lignes de code",
+	"report.violations":      "Violations totales",
+	"report.clean-files":     "Fichiers propres",
+	"report.error-files":     "Fichiers en erreur",
+	"report.cleanliness":     "Propreté",
+	"report.file-lines":      "%d lignes",
+	"report.file-violations": "%d violations",
+	"report.line":            "Ligne",
+	"report.errors":          "FICHIERS NON ANALYSÉS",
+	"report.score":           "SCORE GLOBAL",
+	"report.scoring":         "Barème",
+	"report.rank-0":          "EXCELLENT! Code très propre.",
+	"report.rank-1":          "TRÈS BIEN! Quelques petits détails à corriger.",
+	"report.rank-2":          "CORRECT! Plusieurs améliorations nécessaires.",
+	"report.rank-3":          "ÉCHEC! Beaucoup de travail nécessaire.",
+
+	// rules and explain commands
+	"rules.header":     "CODE\tNIVEAU\tSÉVÉRITÉ\tÉTAT\tNOM",
+	"rules.enabled":    "active",
+	"rules.disabled":   "désactivée",
+	"rules.optional":   "optionnelle",
+	"rules.level":      "niveau %d",
+	"explain.summary":  "%s (%s, niveau %d)",
+	"explain.bad":      "Incorrect :",
+	"explain.good":     "Correct :",
+	"tui.title":        " EPICSTYLE  note %s  %d fichiers  %d violations  filtre : %s  ordre : %s",
+	"tui.all":          "tout",
+	"tui.help":         " ↑↓ déplacer  ←→ panneau  s sévérité  r règle  c effacer  o ordre  e éditer  q quitter",
+	"tui.worst-first":  "moins bons d'abord",
+	"tui.by-name":      "nom",
+	"tui.rule-prompt":  " règle : ",
+	"tui.no-violation": " Aucune violation",
+
+	// Rules
+	"rule.C-L1":              "Longueur de ligne",
+	"rule.C-L1.description":  "Ligne trop longue (80 caractères max)",
+	"rule.C-L2":              "Lignes vides",
+	"rule.C-L2.description":  "Lignes vides interdites",
+	"rule.C-L3":              "Indentation",
+	"rule.C-L3.description":  "Indentation par tabulations uniquement",
+	"rule.C-L4":              "Déclaration de variables",
+	"rule.C-L4.description":  "Une variable par ligne",
+	"rule.C-L5":              "Déclaration dans une boucle for",
+	"rule.C-L5.description":  "Pas de déclaration dans les boucles for",
+	"rule.C-L6":              "Espacement",
+	"rule.C-L6.description":  "Espaces autour des opérateurs, après les mots-clés et les virgules",
+	"rule.C-L7":              "Placement des accolades",
+	"rule.C-L7.description":  "Placement des accolades",
+	"rule.C-V1":              "Position des variables",
+	"rule.C-V1.description":  "Variables en début de fonction",
+	"rule.C-V2":              "Nom de typedef",
+	"rule.C-V2.description":  "Typedef en snake_case terminé par _t",
+	"rule.C-V3":              "Nom d'étiquette de type",
+	"rule.C-V3.description":  "Étiquettes de struct, union et enum en snake_case",
+	"rule.C-V4":              "Nom de constante d'enum",
+	"rule.C-V4.description":  "Constantes d'enum en SCREAMING_SNAKE_CASE",
+	"rule.C-V5":              "Nom de constante globale",
+	"rule.C-V5.description":  "Constantes globales en SCREAMING_SNAKE_CASE",
+	"rule.C-V6":              "Nom de variable locale",
+	"rule.C-V6.description":  "Variables locales en snake_case",
+	"rule.C-V7":              "Nom de paramètre",
+	"rule.C-V7.description":  "Paramètres en snake_case",
+	"rule.C-V8":              "Déclaration de pointeur",
+	"rule.C-V8.description":  "Astérisque collé au nom déclaré",
+	"rule.C-O1":              "Nom de fichier",
+	"rule.C-O1.description":  "Nom de fichier en snake_case",
+	"rule.C-O2":              "Nombre de fonctions",
+	"rule.C-O2.description":  "3 fonctions max par fichier",
+	"rule.C-O3":              "Code dupliqué",
+	"rule.C-O3.description":  "Pas de code dupliqué entre les fichiers",
+	"rule.C-O4":              "Fichiers livrés",
+	"rule.C-O4.description":  "Pas d'artefacts de compilation, de binaires ni de fichiers temporaires",
+	"rule.C-F1":              "Nom de fonction",
+	"rule.C-F1.description":  "Nom de fonction en snake_case",
+	"rule.C-F2":              "Nom de macro",
+	"rule.C-F2.description":  "Macro en SCREAMING_SNAKE_CASE",
+	"rule.C-F3":              "Longueur de fonction",
+	"rule.C-F3.description":  "Fonction de 25 lignes max",
+	"rule.C-F4":              "Paramètres de fonction",
+	"rule.C-F4.description":  "4 paramètres max",
+	"rule.C-F5":              "Liste de paramètres vide",
+	"rule.C-F5.description":  "Une liste de paramètres vide doit être (void)",
+	"rule.C-F6":              "Structure par valeur",
+	"rule.C-F6.description":  "Structures passées par pointeur",
+	"rule.C-F7":              "Commentaire dans une fonction",
+	"rule.C-F7.description":  "Pas de commentaires dans le corps des fonctions",
+	"rule.C-F8":              "Fonction imbriquée",
+	"rule.C-F8.description":  "Pas de définitions de fonctions imbriquées",
+	"rule.C-F9":              "Fonction statique inutilisée",
+	"rule.C-F9.description":  "Les fonctions statiques doivent être utilisées",
+	"rule.C-F10":             "Complexité de fonction",
+	"rule.C-F10.description": "Complexité cyclomatique sous le seuil",
+	"rule.C-C1":              "Format des commentaires",
+	"rule.C-C1.description":  "Commentaires /* */ uniquement",
+	"rule.C-C2":              "Commentaire de fonction",
+	"rule.C-C2.description":  "Commentaire de fonction obligatoire",
+	"rule.C-G1":              "Variables globales",
+	"rule.C-G1.description":  "Pas de globales non constantes",
+	"rule.C-G2":              "Sauts de ligne",
+	"rule.C-G2.description":  "Lignes vides entre les fonctions et après les déclarations",
+	"rule.C-G3":              "Directives du préprocesseur",
+	"rule.C-G3.description":  "Indentation des directives et placement des includes",
+	"rule.C-G4":              "Nombres magiques",
+	"rule.C-G4.description":  "Pas de littéraux numériques en dur",
+	"rule.C-G5":              "Fonctions interdites",
+	"rule.C-G5.description":  "Seules les fonctions autorisées peuvent être appelées",
+	"rule.C-G6":              "En-têtes interdits",
+	"rule.C-G6.description":  "Seuls les en-têtes autorisés peuvent être inclus",
+
+	// Violations: the message, then the format of the description
+	"line-too-long":                                    "Ligne trop longue",
+	"line-too-long.description":                        "La ligne occupe %d colonnes (80 max)",
+	"empty-line-at-start":                              "Ligne vide en début de fichier",
+	"empty-line-at-start.description":                  "Le fichier ne doit pas commencer par une ligne vide",
+	"empty-line-at-end":                                "Ligne vide en fin de fichier",
+	"empty-line-at-end.description":                    "Le fichier ne doit pas finir par une ligne vide",
+	"consecutive-empty-lines":                          "Lignes vides consécutives",
+	"consecutive-empty-lines.description":              "Plusieurs lignes vides consécutives sont interdites",
+	"space-indentation":                                "Indentation par espaces",
+	"space-indentation.description":                    "Indentez avec des tabulations, pas des espaces",
+	"multiple-variable-declaration":                    "Déclaration de plusieurs variables",
+	"multiple-variable-declaration.description":        "Ne déclarez qu'une variable par ligne",
+	"for-loop-declaration":                             "Déclaration de variable dans une boucle for",
+	"for-loop-declaration.description":                 "Ne déclarez pas de variables dans l'initialisation d'une boucle for",
+	"missing-space-after-keyword":                      "Espacement invalide",
+	"missing-space-after-keyword.description":          "Espace manquant après le mot-clé '%s'",
+	"missing-space-after-comma":                        "Espacement invalide",
+	"missing-space-after-comma.description":            "Espace manquant après ','",
+	"space-before-semicolon":                           "Espacement invalide",
+	"space-before-semicolon.description":               "Espace inattendu avant ';'",
+	"space-after-parenthesis":                          "Espacement invalide",
+	"space-after-parenthesis.description":              "Espace inattendu après '('",
+	"space-before-parenthesis":                         "Espacement invalide",
+	"space-before-parenthesis.description":             "Espace inattendu avant ')'",
+	"missing-space-around-operator":                    "Espacement invalide",
+	"missing-space-around-operator.description":        "Espaces manquants autour de l'opérateur '%s'",
+	"misplaced-else":                                   "'else' mal placé",
+	"misplaced-else.description":                       "'else' doit être sur la même ligne que l'accolade fermante : '} else'",
+	"misplaced-while":                                  "'while' mal placé",
+	"misplaced-while.description":                      "'while' doit être sur la même ligne que l'accolade fermante : '} while'",
+	"misplaced-function-brace":                         "Accolade de fonction mal placée",
+	"misplaced-function-brace.description":             "L'accolade ouvrante d'une fonction doit être seule sur sa ligne",
+	"misplaced-control-brace":                          "Accolade de structure de contrôle mal placée",
+	"misplaced-control-brace.description":              "L'accolade ouvrante d'une structure de contrôle doit être en fin de ligne",
+	"code-after-control-brace":                         "Accolade de structure de contrôle mal placée",
+	"code-after-control-brace.description":             "Rien ne doit suivre l'accolade ouvrante d'une structure de contrôle sur la même ligne",
+	"misplaced-brace":                                  "Accolade mal placée",
+	"misplaced-brace.description":                      "L'accolade ouvrante doit être sur la même ligne que la déclaration",
+	"misplaced-closing-brace":                          "Accolade fermante mal placée",
+	"misplaced-closing-brace.description":              "L'accolade fermante doit être seule sur sa ligne",
+	"code-after-function-brace":                        "Accolade fermante mal placée",
+	"code-after-function-brace.description":            "Rien ne doit suivre l'accolade fermante d'une fonction sur la même ligne",
+	"invalid-typedef-name":                             "Nom de typedef invalide",
+	"invalid-typedef-name.description":                 "Le typedef '%s' doit être en snake_case et finir par '_t'",
+	"invalid-typedef-name-pattern":                     "Nom de typedef invalide",
+	"invalid-typedef-name-pattern.description":         "Le typedef '%s' doit correspondre à '%s'",
+	"invalid-tag-name":                                 "Nom d'étiquette de type invalide",
+	"invalid-tag-name.description":                     "L'étiquette de type '%s' doit être en snake_case",
+	"invalid-tag-name-pattern":                         "Nom d'étiquette de type invalide",
+	"invalid-tag-name-pattern.description":             "L'étiquette de type '%s' doit correspondre à '%s'",
+	"invalid-enum-constant-name":                       "Nom de constante d'enum invalide",
+	"invalid-enum-constant-name.description":           "La constante d'enum '%s' doit être en SCREAMING_SNAKE_CASE",
+	"invalid-enum-constant-name-pattern":               "Nom de constante d'enum invalide",
+	"invalid-enum-constant-name-pattern.description":   "La constante d'enum '%s' doit correspondre à '%s'",
+	"invalid-global-constant-name":                     "Nom de constante globale invalide",
+	"invalid-global-constant-name.description":         "La constante globale '%s' doit être en SCREAMING_SNAKE_CASE",
+	"invalid-global-constant-name-pattern":             "Nom de constante globale invalide",
+	"invalid-global-constant-name-pattern.description": "La constante globale '%s' doit correspondre à '%s'",
+	"invalid-local-variable-name":                      "Nom de variable locale invalide",
+	"invalid-local-variable-name.description":          "La variable locale '%s' doit être en snake_case",
+	"invalid-local-variable-name-pattern":              "Nom de variable locale invalide",
+	"invalid-local-variable-name-pattern.description":  "La variable locale '%s' doit correspondre à '%s'",
+	"invalid-parameter-name":                           "Nom de paramètre invalide",
+	"invalid-parameter-name.description":               "Le paramètre '%s' doit être en snake_case",
+	"invalid-parameter-name-pattern":                   "Nom de paramètre invalide",
+	"invalid-parameter-name-pattern.description":       "Le paramètre '%s' doit correspondre à '%s'",
+	"misplaced-pointer-asterisk":                       "Astérisque de pointeur mal placé",
+	"misplaced-pointer-asterisk.description":           "Collez l'astérisque au nom : '%s'",
+	"invalid-filename":                                 "Format de nom de fichier invalide",
+	"invalid-filename.description":                     "Le nom de fichier doit être en snake_case",
+	"too-many-functions":                               "Trop de fonctions",
+	"too-many-functions.description":                   "Le fichier contient %d fonctions (3 max hors main)",
+	"duplicated-code":                                  "Code dupliqué",
+	"duplicated-code.description":                      "%d tokens de '%s' dupliquent %s:%d dans '%s'",
+	"forbidden-delivery-file":                          "Fichier interdit dans le rendu",
+	"forbidden-delivery-file.description":              "'%s' (%s) ne doit pas être rendu",
+	"invalid-function-name":                            "Nom de fonction invalide",
+	"invalid-function-name.description":                "La fonction '%s' doit être en snake_case",
+	"invalid-macro-name":                               "Nom de macro invalide",
+	"invalid-macro-name.description":                   "La macro '%s' doit être en SCREAMING_SNAKE_CASE",
+	"function-too-long":                                "Fonction trop longue",
+	"function-too-long.description":                    "La fonction '%s' fait %d lignes (25 max)",
+	"too-many-parameters":                              "Trop de paramètres",
+	"too-many-parameters.description":                  "La fonction '%s' a %d paramètres (4 max)",
+	"empty-parameter-list":                             "Liste de paramètres vide",
+	"empty-parameter-list.description":                 "La fonction '%[1]s' ne prend aucun paramètre et doit être déclarée '%[1]s(void)'",
+	"structure-by-value":                               "Structure passée par valeur",
+	"structure-by-value.description":                   "Le paramètre '%s' de type '%s' doit être passé par pointeur",
+	"comment-in-function":                              "Commentaire dans une fonction",
+	"comment-in-function.description":                  "Les commentaires sont interdits dans le corps de '%s', documentez-la au-dessus de sa définition",
+	"nested-function":                                  "Définition de fonction imbriquée",
+	"nested-function.description":                      "La fonction '%s' est définie dans une autre fonction",
+	"unused-static-function":                           "Fonction statique inutilisée",
+	"unused-static-function.description":               "La fonction statique '%s' n'est jamais utilisée dans ce fichier",
+	"function-too-complex":                             "Fonction trop complexe",
+	"function-too-complex.description":                 "La fonction '%s' a une complexité cyclomatique de %d (%d max)",
+	"invalid-comment-format":                           "Format de commentaire invalide",
+	"invalid-comment-format.description":               "Utilisez uniquement des commentaires /* */, pas //",
+	"blank-line-after-comment":                         "Ligne vide après le commentaire d'en-tête",
+	"blank-line-after-comment.description":             "Aucune ligne vide n'est permise entre le commentaire de '%s' et sa définition",
+	"function-separation":                              "Séparation invalide entre les fonctions",
+	"function-separation.description":                  "Exactement une ligne vide doit précéder '%s' (%d trouvées)",
+	"missing-blank-after-declarations":                 "Ligne vide manquante après les déclarations",
+	"missing-blank-after-declarations.description":     "Séparez les déclarations de '%s' de ses instructions par une ligne vide",
+	"blank-line-in-function":                           "Ligne vide dans une fonction",
+	"blank-line-in-function.description":               "Seule la ligne qui suit les déclarations de '%s' peut être vide",
+	"unbalanced-directive":                             "Directive conditionnelle déséquilibrée",
+	"unbalanced-directive.description":                 "'#%s' sans '#if' correspondant",
+	"misaligned-directive":                             "Directive conditionnelle mal alignée",
+	"misaligned-directive.description":                 "'#%s' doit être aligné avec son '#if'",
+	"misplaced-include":                                "Include mal placé",
+	"misplaced-include.description":                    "Les directives #include doivent être en haut du fichier, avant tout code",
+	"included-source-file":                             "Fichier source inclus",
+	"included-source-file.description":                 "'%s' est un fichier source et ne doit pas être inclus",
+	"indented-directive":                               "Directive indentée",
+	"indented-directive.description":                   "'#%s' hors de tout bloc conditionnel ne doit pas être indenté",
+	"unindented-directive":                             "Directive non indentée",
+	"unindented-directive.description":                 "'#%[1]s' doit être indenté dans son bloc conditionnel, comme dans '#    %[1]s'",
+	"magic-number":                                     "Nombre magique",
+	"magic-number.description":                         "Nombre magique %s dans '%s', utilisez une constante nommée",
+	"forbidden-function":                               "Fonction interdite",
+	"forbidden-function.description":                   "La fonction '%s' n'est pas autorisée dans ce projet",
+	"forbidden-header":                                 "En-tête interdit",
+	"forbidden-header.description":                     "L'en-tête '%s' n'est pas autorisé dans ce projet",
+
+	// Kinds of files that must not be delivered
+	"term.object-file":     "fichier objet",
+	"term.static-library":  "bibliothèque statique",
+	"term.shared-library":  "bibliothèque partagée",
+	"term.coverage-data":   "données de couverture",
+	"term.coverage-notes":  "notes de couverture",
+	"term.core-dump":       "core dump de valgrind",
+	"term.editor-backup":   "sauvegarde d'éditeur",
+	"term.editor-autosave": "sauvegarde automatique d'éditeur",
+	"term.compiled-binary": "binaire compilé",
+}
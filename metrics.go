@@ -0,0 +1,189 @@
+// metrics.go
+package main
+
+const defaultMaxComplexity = 10
+
+type FileMetrics struct {
+	Functions []FunctionMetrics `json:"functions"`
+}
+
+type FunctionMetrics struct {
+	Name       string `json:"name"`
+	Line       int    `json:"line"`
+	Complexity int    `json:"complexity"`
+	MaxNesting int    `json:"max_nesting"`
+	Statements int    `json:"statements"`
+	Returns    int    `json:"returns"`
+}
+
+// computeMetrics measures every function defined in the file.
+func computeMetrics(analysis *FileAnalysis) *FileMetrics {
+	metrics := &FileMetrics{Functions: []FunctionMetrics{}}
+	for _, fn := range analysis.FunctionDecls {
+		if fn.Prototype || fn.BodyClose >= len(analysis.Code) {
+			continue
+		}
+		metrics.Functions = append(metrics.Functions, measureFunction(analysis.Code, fn))
+	}
+	return metrics
+}
+
+func measureFunction(code []Token, fn FunctionDecl) FunctionMetrics {
+	m := FunctionMetrics{
+		Name:       fn.Name.Text,
+		Line:       fn.Name.Line,
+		Complexity: 1,
+	}
+	walker := nestingWalker{code: code, end: fn.BodyClose}
+	walker.statement(fn.BodyOpen, 0)
+	m.MaxNesting = walker.max
+
+	parens := 0
+	for k := fn.BodyOpen; k <= fn.BodyClose; k++ {
+		tok := code[k]
+		switch tok.Text {
+		case "(":
+			parens++
+		case ")":
+			parens--
+		case ";":
+			if parens == 0 {
+				m.Statements++
+			}
+		case "if", "for", "switch":
+			m.Statements++
+			if tok.Text != "switch" {
+				m.Complexity++
+			}
+		case "while":
+			// The ';' ending a do-while already counts as its statement
+			m.Complexity++
+			if code[k-1].Text != "}" || !isDoBlockClose(code, k-1) {
+				m.Statements++
+			}
+		case "case", "&&", "||", "?":
+			m.Complexity++
+		case "return":
+			m.Returns++
+		}
+	}
+	return m
+}
+
+// nestingWalker follows the statements of a function body to find how
+// deeply control statements are nested. Plain blocks and initializer
+// braces do not add a level, while a body without braces does.
+type nestingWalker struct {
+	code []Token
+	end  int // index of the '}' closing the body
+	max  int
+}
+
+// statement walks the statement starting at index k, found under depth
+// control statements, and returns the index following it.
+func (w *nestingWalker) statement(k, depth int) int {
+	if k >= w.end {
+		return w.end
+	}
+	if depth > w.max {
+		w.max = depth
+	}
+	code := w.code
+	switch keyword := code[k].Text; keyword {
+	case "{":
+		k++
+		for k < w.end && code[k].Text != "}" {
+			k = w.statement(k, depth)
+		}
+		return k + 1
+	case "if", "for", "while", "switch":
+		k = w.statement(w.skipParens(k+1), depth+1)
+		if keyword != "if" || k >= w.end || code[k].Text != "else" {
+			return k
+		}
+		// An else if chain stays at the level of its first if
+		if k+1 < w.end && code[k+1].Text == "if" {
+			return w.statement(k+1, depth)
+		}
+		return w.statement(k+1, depth+1)
+	case "do":
+		k = w.statement(k+1, depth+1)
+		if k < w.end && code[k].Text == "while" {
+			k = w.skipParens(k + 1)
+		}
+		if k < w.end && code[k].Text == ";" {
+			k++
+		}
+		return k
+	case "case", "default":
+		for k < w.end && code[k].Text != ":" {
+			k++
+		}
+		return k + 1
+	}
+
+	// Expression or declaration, up to its ';'
+	level := 0
+	for ; k < w.end; k++ {
+		switch code[k].Text {
+		case "(", "[", "{":
+			level++
+		case ")", "]":
+			level--
+		case "}":
+			if level == 0 {
+				return k
+			}
+			level--
+		case ";":
+			if level <= 0 {
+				return k + 1
+			}
+		}
+	}
+	return k
+}
+
+// skipParens returns the index following the parenthesized group at k,
+// or k itself when there is none.
+func (w *nestingWalker) skipParens(k int) int {
+	if k >= w.end || w.code[k].Text != "(" {
+		return k
+	}
+	level := 0
+	for ; k < w.end; k++ {
+		switch w.code[k].Text {
+		case "(":
+			level++
+		case ")":
+			level--
+			if level == 0 {
+				return k + 1
+			}
+		}
+	}
+	return k
+}
+
+// checkComplexity flags functions whose cyclomatic complexity exceeds the
+// configured threshold.
+func checkComplexity(analysis *FileAnalysis, filename string, lineNum int) []Violation {
+	var violations []Violation
+	threshold := analysis.Config.Threshold("C-F10", defaultMaxComplexity)
+	if analysis.Metrics == nil {
+		return violations
+	}
+	for _, m := range analysis.Metrics.Functions {
+		if m.Complexity <= threshold {
+			continue
+		}
+		violations = append(violations, atWord(Violation{
+			Rule:      "C-F10",
+			MessageID: "function-too-complex",
+			Args:      []any{m.Name, m.Complexity, threshold},
+			Line:      m.Line,
+			Severity:  SeverityMajor,
+		}, analysis.Lines, m.Name))
+	}
+	return violations
+}
@@ -0,0 +1,132 @@
+// metrics_test.go
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestComputeMetrics(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want FunctionMetrics
+	}{
+		{"empty", "{\n}\n", FunctionMetrics{Complexity: 1}},
+		{"plain blocks", "{\n\t{\n\t\tint a = 1;\n\t}\n\treturn 0;\n}\n",
+			FunctionMetrics{Complexity: 1, Statements: 2, Returns: 1}},
+		{"braced if", "{\n\tif (a) {\n\t\tx();\n\t}\n}\n",
+			FunctionMetrics{Complexity: 2, MaxNesting: 1, Statements: 2}},
+		{"nested ifs without braces", "{\n\tif (a)\n\t\tif (b)\n\t\t\tx();\n}\n",
+			FunctionMetrics{Complexity: 3, MaxNesting: 2, Statements: 3}},
+		{"else if chain", "{\n\tif (a)\n\t\tx();\n\telse if (b)\n\t\ty();\n\telse\n\t\tz();\n}\n",
+			FunctionMetrics{Complexity: 3, MaxNesting: 1, Statements: 5}},
+		{"initializer", "{\n\tint t[2] = {1, 2};\n\tpoint_t p = {.x = {0}};\n}\n",
+			FunctionMetrics{Complexity: 1, Statements: 2}},
+		{"loops", "{\n\tfor (i = 0; i < n && t[i]; i++)\n\t\twhile (x())\n\t\t\ty();\n\tdo {\n\t\tz();\n\t} while (a);\n}\n",
+			FunctionMetrics{Complexity: 5, MaxNesting: 2, Statements: 5}},
+		{"switch", "{\n\tswitch (a) {\n\tcase 1:\n\t\treturn b ? 1 : 2;\n\tdefault:\n\t\tif (c)\n\t\t\treturn 0;\n\t}\n\treturn -1;\n}\n",
+			FunctionMetrics{Complexity: 4, MaxNesting: 2, Statements: 5, Returns: 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := "int foo(int a)\n" + tt.body
+			analysis := NewAnalyzer(2, DefaultConfig()).newFileAnalysis("test.c", []byte(source))
+			want := tt.want
+			want.Name, want.Line = "foo", 1
+			if got := analysis.Metrics.Functions; !reflect.DeepEqual(got, []FunctionMetrics{want}) {
+				t.Errorf("got %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestCheckComplexity(t *testing.T) {
+	source := "int simple(int a)\n{\n\treturn a;\n}\n\n" +
+		"int branchy(int a)\n{\n\tif (a && a > 1 || a < -1)\n\t\treturn 1;\n\treturn a ? 2 : 3;\n}\n"
+	tests := []struct {
+		threshold int // 0 keeps the default
+		lines     []int
+	}{
+		{0, nil},
+		{5, nil},
+		{4, []int{6}},
+		{1, []int{6}},
+	}
+	for _, tt := range tests {
+		config := DefaultConfig()
+		config.Rules["C-F10"] = RuleConfig{Threshold: tt.threshold}
+		analysis := NewAnalyzer(2, config).newFileAnalysis("test.c", []byte(source))
+		var lines []int
+		for _, v := range checkComplexity(analysis, "test.c", 0) {
+			if v.Rule != "C-F10" {
+				t.Errorf("threshold %d: rule %s, want C-F10", tt.threshold, v.Rule)
+			}
+			lines = append(lines, v.Line)
+		}
+		if !reflect.DeepEqual(lines, tt.lines) {
+			t.Errorf("threshold %d: got lines %v, want %v", tt.threshold, lines, tt.lines)
+		}
+	}
+}
+
+func TestComplexityRuleOptional(t *testing.T) {
+	source := "int foo(int a)\n{\n\tif (a && a > 1)\n\t\treturn 1;\n\treturn 0;\n}\n"
+	enabled := true
+	for _, rule := range []RuleConfig{{Threshold: 1}, {Enabled: &enabled, Threshold: 1}} {
+		config := DefaultConfig()
+		config.Rules["C-F10"] = rule
+		analysis := NewAnalyzer(2, config).newFileAnalysis("test.c", []byte(source))
+		violations, err := NewAnalyzer(2, config).runRules(analysis)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reported := false
+		for _, v := range violations {
+			reported = reported || v.Rule == "C-F10"
+		}
+		if reported != (rule.Enabled != nil) {
+			t.Errorf("enabled %v: C-F10 reported = %v", rule.Enabled != nil, reported)
+		}
+	}
+}
+
+func TestMetricsJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.c")
+	source := "int foo(int a)\n{\n\tif (a)\n\t\treturn 1;\n\treturn 0;\n}\n"
+	if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+	analyzer := NewAnalyzer(2, DefaultConfig())
+	result, err := analyzer.analyzeFile(analyzer.newFileAnalysis(path, []byte(source)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded struct {
+		Metrics struct {
+			Functions []map[string]interface{} `json:"functions"`
+		} `json:"metrics"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	want := []map[string]interface{}{{
+		"name":        "foo",
+		"line":        1.0,
+		"complexity":  2.0,
+		"max_nesting": 1.0,
+		"statements":  3.0,
+		"returns":     2.0,
+	}}
+	if !reflect.DeepEqual(decoded.Metrics.Functions, want) {
+		t.Errorf("metrics block: got %v, want %v", decoded.Metrics.Functions, want)
+	}
+}
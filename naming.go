@@ -0,0 +1,101 @@
+// naming.go
+package main
+
+import "strings"
+
+// namingRule describes an identifier naming check applied to one kind of
+// declaration. The default check is used unless the configuration gives a
+// pattern for the rule.
+type namingRule struct {
+	code    string
+	id      string // message ID, suffixed with "-pattern" for configured patterns
+	applies func(Declaration) bool
+	valid   func(string) bool
+}
+
+var namingRules = map[string]namingRule{
+	"C-V2": {
+		code: "C-V2", id: "invalid-typedef-name",
+		applies: func(d Declaration) bool { return d.Kind == DeclTypedef },
+		valid:   isTypedefName,
+	},
+	"C-V3": {
+		code: "C-V3", id: "invalid-tag-name",
+		applies: func(d Declaration) bool { return d.Kind == DeclTag },
+		valid:   isSnakeCase,
+	},
+	"C-V4": {
+		code: "C-V4", id: "invalid-enum-constant-name",
+		applies: func(d Declaration) bool { return d.Kind == DeclEnumConstant },
+		valid:   isScreamingSnakeCase,
+	},
+	"C-V5": {
+		code: "C-V5", id: "invalid-global-constant-name",
+		applies: func(d Declaration) bool { return d.Kind == DeclGlobal && d.Const },
+		valid:   isScreamingSnakeCase,
+	},
+	"C-V6": {
+		code: "C-V6", id: "invalid-local-variable-name",
+		applies: func(d Declaration) bool { return d.Kind == DeclLocal },
+		valid:   isSnakeCase,
+	},
+	"C-V7": {
+		code: "C-V7", id: "invalid-parameter-name",
+		applies: func(d Declaration) bool { return d.Kind == DeclParam },
+		valid:   isSnakeCase,
+	},
+}
+
+func checkTypedefNames(analysis *FileAnalysis, filename string, lineNum int) []Violation {
+	return checkNaming(analysis, namingRules["C-V2"])
+}
+
+func checkTagNames(analysis *FileAnalysis, filename string, lineNum int) []Violation {
+	return checkNaming(analysis, namingRules["C-V3"])
+}
+
+func checkEnumConstantNames(analysis *FileAnalysis, filename string, lineNum int) []Violation {
+	return checkNaming(analysis, namingRules["C-V4"])
+}
+
+func checkGlobalConstantNames(analysis *FileAnalysis, filename string, lineNum int) []Violation {
+	return checkNaming(analysis, namingRules["C-V5"])
+}
+
+func checkLocalVariableNames(analysis *FileAnalysis, filename string, lineNum int) []Violation {
+	return checkNaming(analysis, namingRules["C-V6"])
+}
+
+func checkParameterNames(analysis *FileAnalysis, filename string, lineNum int) []Violation {
+	return checkNaming(analysis, namingRules["C-V7"])
+}
+
+func checkNaming(analysis *FileAnalysis, rule namingRule) []Violation {
+	var violations []Violation
+	valid := rule.valid
+	id := rule.id
+	var pattern []any
+	if re := analysis.Config.Pattern(rule.code); re != nil {
+		valid = re.MatchString
+		id += "-pattern"
+		pattern = append(pattern, re.String())
+	}
+
+	for _, decl := range analysis.Declarations {
+		if !rule.applies(decl) || valid(decl.Name.Text) {
+			continue
+		}
+		violations = append(violations, atToken(Violation{
+			Rule:      rule.code,
+			MessageID: id,
+			Args:      append([]any{decl.Name.Text}, pattern...),
+			Line:      decl.Name.Line,
+			Severity:  SeverityMajor,
+		}, decl.Name))
+	}
+	return violations
+}
+
+func isTypedefName(s string) bool {
+	return isSnakeCase(s) && strings.HasSuffix(s, "_t") && len(s) > 2
+}
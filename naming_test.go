@@ -0,0 +1,122 @@
+// naming_test.go
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// parseSource prepares a source for the token-based rules.
+func parseSource(source string, config *Config) *FileAnalysis {
+	return NewAnalyzer(2, config).newFileAnalysis("test.c", []byte(source))
+}
+
+// namingSource declares one name of each kind per line, valid names first.
+const namingSource = `typedef int my_int_t;
+typedef int MyInt;
+struct my_struct {
+	int field;
+};
+union MyUnion { int value; };
+enum color { RED, Green };
+const int MAX_SIZE = 4;
+const int maxSize = 4;
+static int counter = 0;
+int func(int good_param, int BadParam)
+{
+	int good_local;
+	int badLocal;
+
+	return 0;
+}
+`
+
+func TestNamingRules(t *testing.T) {
+	tests := []struct {
+		code  string
+		lines []int
+	}{
+		{"C-V2", []int{2}},
+		{"C-V3", []int{6}},
+		{"C-V4", []int{7}},
+		{"C-V5", []int{9}},
+		{"C-V6", []int{14}},
+		{"C-V7", []int{11}},
+	}
+	analysis := parseSource(namingSource, DefaultConfig())
+	for _, tt := range tests {
+		var lines []int
+		for _, v := range checkNaming(analysis, namingRules[tt.code]) {
+			if v.Rule != tt.code {
+				t.Errorf("%s reported a %s violation", tt.code, v.Rule)
+			}
+			lines = append(lines, v.Line)
+		}
+		if !reflect.DeepEqual(lines, tt.lines) {
+			t.Errorf("%s reported lines %v, want %v", tt.code, lines, tt.lines)
+		}
+	}
+}
+
+func TestNamingPredicates(t *testing.T) {
+	tests := []struct {
+		name          string
+		snake, scream bool
+		typedef       bool
+	}{
+		{"my_var", true, false, false},
+		{"my_var_t", true, false, true},
+		{"MY_VAR", false, true, false},
+		{"myVar", false, false, false},
+		{"var2", true, false, false},
+	}
+	for _, tt := range tests {
+		if got := isSnakeCase(tt.name); got != tt.snake {
+			t.Errorf("isSnakeCase(%q) = %v, want %v", tt.name, got, tt.snake)
+		}
+		if got := isScreamingSnakeCase(tt.name); got != tt.scream {
+			t.Errorf("isScreamingSnakeCase(%q) = %v, want %v", tt.name, got, tt.scream)
+		}
+		if got := isTypedefName(tt.name); got != tt.typedef {
+			t.Errorf("isTypedefName(%q) = %v, want %v", tt.name, got, tt.typedef)
+		}
+	}
+}
+
+func TestNamingPattern(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	content := `{"rules": {"C-V6": {"pattern": "^[a-z][a-zA-Z0-9]*$"}, "C-V7": {"enabled": false}}}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.IsEnabled("C-V7", true) || !config.IsEnabled("C-V6", true) {
+		t.Errorf("IsEnabled(C-V7) = %v, IsEnabled(C-V6) = %v, want false and true",
+			config.IsEnabled("C-V7", true), config.IsEnabled("C-V6", true))
+	}
+
+	// camelCase is now accepted, snake_case with an underscore is not
+	analysis := parseSource(namingSource, config)
+	var lines []int
+	for _, v := range checkNaming(analysis, namingRules["C-V6"]) {
+		lines = append(lines, v.Line)
+	}
+	if !reflect.DeepEqual(lines, []int{13}) {
+		t.Errorf("C-V6 with a pattern reported lines %v, want [13]", lines)
+	}
+}
+
+func TestLoadConfigInvalidPattern(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"rules": {"C-V2": {"pattern": "("}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(path); err == nil {
+		t.Error("LoadConfig accepted an invalid pattern")
+	}
+}
@@ -0,0 +1,471 @@
+// parser.go
+package main
+
+import "strings"
+
+type DeclKind int
+
+const (
+	DeclGlobal DeclKind = iota
+	DeclLocal
+	DeclParam
+	DeclField
+	DeclTypedef
+	DeclTag
+	DeclEnumConstant
+)
+
+// Declaration is a name introduced by the source: a variable, a parameter,
+// a structure field, a typedef, a tag or an enum constant.
+type Declaration struct {
+	Kind     DeclKind
+	Name     Token
+	Const    bool // the declared object itself is read-only
+	Static   bool
+	Pointer  bool
+	TypeName string // "struct s", "struct" when anonymous, a typedef name, or empty
+	Start    int    // index in the code tokens of the declaration statement
+	End      int    // index of the token ending the statement
+	Function int    // index in FunctionDecls of the enclosing function, -1 if none
+}
+
+// FunctionDecl is a function definition or prototype found in the tokens.
+// Indexes refer to the code tokens (comments and directives excluded).
+type FunctionDecl struct {
+	Name       Token
+	Static     bool
+	Nested     bool
+	Prototype  bool
+	Start      int
+	ParamOpen  int
+	ParamClose int
+	BodyOpen   int
+	BodyClose  int
+}
+
+var storageKeywords = map[string]bool{
+	"static": true, "extern": true, "register": true, "auto": true,
+	"typedef": true, "inline": true,
+}
+
+var statementKeywords = map[string]bool{
+	"if": true, "while": true, "for": true, "switch": true, "do": true,
+	"else": true, "case": true, "default": true, "return": true,
+	"break": true, "continue": true, "goto": true,
+}
+
+type declSpec struct {
+	hasType  bool
+	static   bool
+	typedef  bool
+	constant bool
+	typeName string
+}
+
+type declParser struct {
+	code      []Token
+	typedefs  map[string]bool
+	functions []FunctionDecl
+	decls     []Declaration
+}
+
+// parseDeclarations walks the code tokens of a file and collects the
+// functions and declarations it contains. It is a best-effort parser: it
+// never fails, unknown constructs are skipped up to the next ';'.
+func parseDeclarations(code []Token) ([]FunctionDecl, []Declaration) {
+	p := &declParser{code: code, typedefs: make(map[string]bool)}
+	p.parseScope(0, len(code), DeclGlobal, -1)
+	return p.functions, p.decls
+}
+
+func (p *declParser) parseScope(k, end int, scope DeclKind, fn int) {
+	for k < end {
+		t := p.code[k]
+		switch {
+		case t.Text == ";" || t.Text == "}":
+			k++
+		case t.Text == "{":
+			closing := p.matchClose(k, end)
+			p.parseScope(k+1, closing, scope, fn)
+			k = closing + 1
+		case scope != DeclLocal:
+			k = p.parseDeclaration(k, end, scope, fn)
+		case t.Kind == TokKeyword && statementKeywords[t.Text]:
+			k = p.skipControl(k, end)
+		case t.Kind == TokIdent && k+1 < end && p.code[k+1].Text == ":":
+			k += 2
+		case p.startsDeclaration(k, end):
+			k = p.parseDeclaration(k, end, scope, fn)
+		default:
+			k = p.skipStatement(k, end)
+		}
+	}
+}
+
+// startsDeclaration guesses whether the local statement at index k declares
+// variables rather than evaluating an expression.
+func (p *declParser) startsDeclaration(k, end int) bool {
+	t := p.code[k]
+	if t.Kind == TokKeyword {
+		return typeKeywords[t.Text] || storageKeywords[t.Text] || isTagKeyword(t.Text)
+	}
+	if t.Kind != TokIdent || k+1 >= end {
+		return false
+	}
+	next := p.code[k+1]
+	if next.Kind == TokIdent {
+		return true
+	}
+	if next.Text != "*" {
+		return false
+	}
+	if p.typedefs[t.Text] || strings.HasSuffix(t.Text, "_t") {
+		return true
+	}
+	// "type *name;" with the star glued to the name
+	j := k + 1
+	for j < end && p.code[j].Text == "*" {
+		j++
+	}
+	if j+1 >= end || p.code[j].Kind != TokIdent {
+		return false
+	}
+	switch p.code[j+1].Text {
+	case ";", "=", ",", "[":
+		return p.code[k+1].Offset > t.End() && p.code[j].Offset == p.code[j-1].End()
+	}
+	return false
+}
+
+func (p *declParser) parseDeclaration(k, end int, scope DeclKind, fn int) int {
+	start := k
+	first := len(p.decls)
+	var spec declSpec
+
+specifiers:
+	for k < end {
+		t := p.code[k]
+		switch {
+		case t.Kind == TokKeyword && storageKeywords[t.Text]:
+			spec.static = spec.static || t.Text == "static"
+			spec.typedef = spec.typedef || t.Text == "typedef"
+			k++
+		case t.Kind == TokKeyword && typeKeywords[t.Text]:
+			if t.Text == "const" {
+				spec.constant = true
+			} else if t.Text != "volatile" && t.Text != "restrict" {
+				spec.hasType = true
+			}
+			k++
+		case t.Kind == TokKeyword && isTagKeyword(t.Text):
+			k = p.parseTagSpecifier(k, end, &spec, fn)
+		case t.Kind == TokIdent && isAttribute(t.Text):
+			k = p.skipAttribute(k, end)
+		case t.Kind == TokIdent && !spec.hasType:
+			spec.hasType = true
+			spec.typeName = t.Text
+			k++
+		default:
+			break specifiers
+		}
+	}
+
+	for k < end {
+		next, done := p.parseDeclarator(k, end, spec, scope, fn, start)
+		k = next
+		if done {
+			return k
+		}
+		// Skip initializers and bit-field widths
+		for k < end && p.code[k].Text != "," && p.code[k].Text != ";" {
+			k = p.skipGroup(k, end)
+		}
+		if k >= end || p.code[k].Text == ";" {
+			break
+		}
+		k++
+	}
+
+	last := k
+	if last >= end {
+		last = end - 1
+	}
+	for d := first; d < len(p.decls); d++ {
+		if p.decls[d].Start == start {
+			p.decls[d].End = last
+		}
+	}
+	if k < end {
+		k++
+	}
+	return k
+}
+
+// parseTagSpecifier handles "struct name { ... }" and friends, recording
+// the tag and the declarations found in the body.
+func (p *declParser) parseTagSpecifier(k, end int, spec *declSpec, fn int) int {
+	keyword := p.code[k].Text
+	spec.hasType = true
+	k++
+	var tag *Token
+	if k < end && p.code[k].Kind == TokIdent {
+		tag = &p.code[k]
+		spec.typeName = keyword + " " + tag.Text
+		k++
+	} else {
+		spec.typeName = keyword
+	}
+	if k >= end || p.code[k].Text != "{" {
+		return k
+	}
+	closing := p.matchClose(k, end)
+	if tag != nil {
+		p.decls = append(p.decls, Declaration{
+			Kind: DeclTag, Name: *tag, TypeName: spec.typeName, Start: k, End: closing, Function: fn,
+		})
+	}
+	if keyword == "enum" {
+		p.parseEnumBody(k+1, closing, fn)
+	} else {
+		p.parseScope(k+1, closing, DeclField, fn)
+	}
+	if closing < end {
+		closing++
+	}
+	return closing
+}
+
+func (p *declParser) parseEnumBody(k, end int, fn int) {
+	expectName := true
+	for k < end {
+		t := p.code[k]
+		switch {
+		case t.Text == ",":
+			expectName = true
+			k++
+		case expectName && t.Kind == TokIdent:
+			p.decls = append(p.decls, Declaration{
+				Kind: DeclEnumConstant, Name: t, Const: true, Start: k, End: k, Function: fn,
+			})
+			expectName = false
+			k++
+		default:
+			k = p.skipGroup(k, end)
+		}
+	}
+}
+
+// parseDeclarator reads one declarator. done is true when the declarator
+// was a function definition whose body has been consumed.
+func (p *declParser) parseDeclarator(k, end int, spec declSpec, scope DeclKind, fn int, start int) (int, bool) {
+	pointer := false
+	constant := spec.constant
+	funcPointer := false
+	for k < end {
+		t := p.code[k]
+		if t.Text == "*" {
+			pointer = true
+			constant = false
+		} else if t.Text == "const" {
+			constant = true
+		} else if t.Text != "volatile" && t.Text != "restrict" {
+			break
+		}
+		k++
+	}
+
+	name := -1
+	if k < end && p.code[k].Kind == TokIdent {
+		name = k
+		k++
+	} else if k+1 < end && p.code[k].Text == "(" && p.code[k+1].Text == "*" {
+		closing := p.matchClose(k, end)
+		for j := k + 1; j < closing; j++ {
+			if p.code[j].Kind == TokIdent {
+				name = j
+				break
+			}
+		}
+		pointer = true
+		funcPointer = true
+		k = closing + 1
+	}
+
+	paramOpen, paramClose := -1, -1
+	for k < end {
+		t := p.code[k]
+		if t.Text == "[" {
+			// Array parameters decay to pointers
+			pointer = pointer || scope == DeclParam
+			k = p.matchClose(k, end) + 1
+		} else if t.Text == "(" {
+			closing := p.matchClose(k, end)
+			if paramOpen < 0 {
+				paramOpen, paramClose = k, closing
+			}
+			k = closing + 1
+		} else if t.Kind == TokIdent && isAttribute(t.Text) {
+			k = p.skipAttribute(k, end)
+		} else {
+			break
+		}
+	}
+
+	if name < 0 {
+		return k, false
+	}
+
+	if paramOpen >= 0 && !funcPointer && !spec.typedef && scope != DeclParam && scope != DeclField {
+		fd := FunctionDecl{
+			Name:       p.code[name],
+			Static:     spec.static,
+			Nested:     scope == DeclLocal,
+			Start:      start,
+			ParamOpen:  paramOpen,
+			ParamClose: paramClose,
+			BodyOpen:   -1,
+			BodyClose:  -1,
+		}
+		index := len(p.functions)
+		if k < end && p.code[k].Text == "{" {
+			fd.BodyOpen = k
+			fd.BodyClose = p.matchClose(k, end)
+		} else {
+			fd.Prototype = true
+		}
+		p.functions = append(p.functions, fd)
+		p.parseParams(paramOpen+1, paramClose, index)
+		if fd.Prototype {
+			return k, false
+		}
+		p.parseScope(fd.BodyOpen+1, fd.BodyClose, DeclLocal, index)
+		return fd.BodyClose + 1, true
+	}
+
+	kind := scope
+	if spec.typedef {
+		kind = DeclTypedef
+		p.typedefs[p.code[name].Text] = true
+	}
+	p.decls = append(p.decls, Declaration{
+		Kind:     kind,
+		Name:     p.code[name],
+		Const:    constant,
+		Static:   spec.static,
+		Pointer:  pointer,
+		TypeName: spec.typeName,
+		Start:    start,
+		End:      k,
+		Function: fn,
+	})
+	return k, false
+}
+
+func (p *declParser) parseParams(k, end int, fn int) {
+	segment := k
+	depth := 0
+	for j := k; j <= end; j++ {
+		if j < end {
+			switch p.code[j].Text {
+			case "(", "[", "{":
+				depth++
+				continue
+			case ")", "]", "}":
+				depth--
+				continue
+			case ",":
+				if depth > 0 {
+					continue
+				}
+			default:
+				continue
+			}
+		}
+		if j > segment && !isVoidParam(p.code[segment:j]) {
+			p.parseDeclaration(segment, j, DeclParam, fn)
+		}
+		segment = j + 1
+	}
+}
+
+func isVoidParam(tokens []Token) bool {
+	return len(tokens) == 1 && (tokens[0].Text == "void" || tokens[0].Text == "...")
+}
+
+func (p *declParser) skipControl(k, end int) int {
+	switch p.code[k].Text {
+	case "if", "while", "for", "switch":
+		k++
+		if k < end && p.code[k].Text == "(" {
+			k = p.matchClose(k, end) + 1
+		}
+		return k
+	case "do", "else":
+		return k + 1
+	case "case", "default":
+		for k < end && p.code[k].Text != ":" {
+			k++
+		}
+		return k + 1
+	}
+	return p.skipStatement(k, end)
+}
+
+// skipStatement moves past the next ';' outside of any group, stopping
+// early at a '}' that closes the current scope.
+func (p *declParser) skipStatement(k, end int) int {
+	for k < end {
+		switch p.code[k].Text {
+		case ";":
+			return k + 1
+		case "}":
+			return k
+		}
+		k = p.skipGroup(k, end)
+	}
+	return k
+}
+
+// skipGroup returns the index after the token at k, or after its matching
+// closing token when it opens a group.
+func (p *declParser) skipGroup(k, end int) int {
+	switch p.code[k].Text {
+	case "(", "[", "{":
+		return p.matchClose(k, end) + 1
+	}
+	return k + 1
+}
+
+// matchClose returns the index of the token closing the group opened at k,
+// or end when the group is not closed.
+func (p *declParser) matchClose(k, end int) int {
+	return matchClose(p.code, k, end)
+}
+
+func matchClose(code []Token, k, end int) int {
+	depth := 0
+	for j := k; j < end; j++ {
+		switch code[j].Text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return end
+}
+
+func isAttribute(name string) bool {
+	return name == "__attribute__" || name == "__declspec" || name == "__asm__"
+}
+
+func (p *declParser) skipAttribute(k, end int) int {
+	k++
+	if k < end && p.code[k].Text == "(" {
+		k = p.matchClose(k, end) + 1
+	}
+	return k
+}
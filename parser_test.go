@@ -0,0 +1,66 @@
+// parser_test.go
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDeclarationsTruncated(t *testing.T) {
+	tests := []struct {
+		name      string
+		source    string
+		functions []string // name, then "proto", "body" or "open" when the body is not closed
+		decls     []string
+	}{
+		{"complete", "int x;\nint foo(int a)\n{\n\tint b;\n}\n", []string{"foo body"}, []string{"x", "a", "b"}},
+		{"unclosed parameter list", "int foo(", []string{"foo proto"}, nil},
+		{"unclosed parameter list with parameter", "int foo(int a", []string{"foo proto"}, []string{"a"}},
+		{"unclosed body", "int foo(void)\n{\n\tint a;\n", []string{"foo open"}, []string{"a"}},
+		{"unclosed nested block", "int foo(void)\n{\n\tif (x) {\n\t\tint a;\n}\n", []string{"foo open"}, []string{"a"}},
+		{"unclosed array", "int tab[", nil, []string{"tab"}},
+		{"unclosed struct", "struct s {\n\tint a;\n", nil, []string{"s", "a"}},
+		{"unclosed enum", "enum e { A, B", nil, []string{"e", "A", "B"}},
+		{"unclosed function pointer", "int (*f", nil, []string{"f"}},
+		{"stray closing brace", "}\nint foo(void);\n", []string{"foo proto"}, nil},
+		{"stray closing parenthesis", "int foo(void))\n{\n}\n", []string{"foo proto"}, nil},
+		{"declaration without semicolon", "int x", nil, []string{"x"}},
+		{"type alone", "static const", nil, nil},
+		{"unclosed control", "int foo(void)\n{\n\twhile (x", []string{"foo open"}, nil},
+		{"unclosed case", "int foo(void)\n{\n\tswitch (x) {\n\tcase 1", []string{"foo open"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := codeTokens(tokenize(tt.source))
+			functions, decls := parseDeclarations(code)
+
+			var gotFunctions, gotDecls []string
+			for _, fn := range functions {
+				for _, index := range []int{fn.Start, fn.ParamOpen, fn.ParamClose, fn.BodyOpen, fn.BodyClose} {
+					if index < -1 || index > len(code) {
+						t.Fatalf("%s has index %d out of the %d code tokens", fn.Name.Text, index, len(code))
+					}
+				}
+				state := "body"
+				if fn.Prototype {
+					state = "proto"
+				} else if fn.BodyClose >= len(code) {
+					state = "open"
+				}
+				gotFunctions = append(gotFunctions, fn.Name.Text+" "+state)
+			}
+			for _, decl := range decls {
+				if decl.Start < 0 || decl.End > len(code) {
+					t.Fatalf("%s spans %d-%d out of the %d code tokens", decl.Name.Text, decl.Start, decl.End, len(code))
+				}
+				gotDecls = append(gotDecls, decl.Name.Text)
+			}
+			if !reflect.DeepEqual(gotFunctions, tt.functions) {
+				t.Errorf("functions = %q, want %q", gotFunctions, tt.functions)
+			}
+			if !reflect.DeepEqual(gotDecls, tt.decls) {
+				t.Errorf("declarations = %q, want %q", gotDecls, tt.decls)
+			}
+		})
+	}
+}
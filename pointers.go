@@ -0,0 +1,109 @@
+// pointers.go
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// checkPointerDeclarations flags declarators where the asterisk is not
+// attached to the declared name, as in "char* str" or "char * str".
+// Casts and abstract parameters have no name and are left alone.
+func checkPointerDeclarations(analysis *FileAnalysis, filename string, lineNum int) []Violation {
+	var violations []Violation
+	var names []Token
+	for _, decl := range analysis.Declarations {
+		if decl.Pointer {
+			names = append(names, decl.Name)
+		}
+	}
+	for _, fn := range analysis.FunctionDecls {
+		names = append(names, fn.Name)
+	}
+
+	for _, name := range names {
+		index := tokenIndex(analysis.Code, name.Offset)
+		if index < 0 {
+			continue
+		}
+		fix := pointerFix(analysis, index)
+		if fix == nil {
+			continue
+		}
+		// Underline from the first misplaced asterisk to the name
+		between := analysis.Source[fix.Offset:name.Offset]
+		start := fix.Offset + len(between) - len(strings.TrimLeft(between, " \t"))
+		violations = append(violations, atOffsets(Violation{
+			Rule:      "C-V8",
+			MessageID: "misplaced-pointer-asterisk",
+			Args:      []any{strings.TrimSpace(fix.Replacement) + name.Text},
+			Line:      name.Line,
+			Severity:  SeverityMinor,
+			Fix:       fix,
+		}, analysis.Source, start, name.End()))
+	}
+	return violations
+}
+
+// pointerFix returns the fix normalizing the asterisks preceding the name
+// at index, or nil when they are already well placed.
+func pointerFix(analysis *FileAnalysis, index int) *Fix {
+	code := analysis.Code
+	first := index
+	hasStar := false
+	for first > 0 {
+		prev := code[first-1]
+		if prev.Text == "*" {
+			hasStar = true
+		} else if prev.Text != "const" && prev.Text != "volatile" && prev.Text != "restrict" {
+			break
+		}
+		first--
+	}
+	if !hasStar || first == 0 {
+		return nil
+	}
+	for first < index && code[first].Text != "*" {
+		first++
+	}
+
+	// Qualifiers before the first star belong to the pointed type. Any
+	// whitespace may separate the type from the first star, so that
+	// declarations aligned with tabs are accepted.
+	expected := ""
+	for k := first; k < index; k++ {
+		expected += code[k].Text
+		if code[k].Text != "*" {
+			expected += " "
+		}
+	}
+
+	start := code[first-1].End()
+	end := code[index].Offset
+	actual := analysis.Source[start:end]
+	if strings.ContainsAny(actual, "\n/") {
+		return nil
+	}
+	rest := strings.TrimLeft(actual, " \t")
+	leading := actual[:len(actual)-len(rest)]
+	if code[first-1].Text == "(" {
+		leading = ""
+	} else if leading == "" {
+		leading = " "
+	}
+	if leading+expected == actual {
+		return nil
+	}
+	return &Fix{Offset: start, Length: end - start, Replacement: leading + expected}
+}
+
+// tokenIndex returns the index of the token starting at offset, or -1.
+func tokenIndex(tokens []Token, offset int) int {
+	i := sort.Search(len(tokens), func(i int) bool {
+		return tokens[i].Offset >= offset
+	})
+	if i < len(tokens) && tokens[i].Offset == offset {
+		return i
+	}
+	return -1
+}
@@ -0,0 +1,124 @@
+// preprocessor.go
+package main
+
+import "strings"
+
+// Directive is a preprocessor line split into its parts.
+type Directive struct {
+	Prefix string // whitespace and '#' before the name
+	Name   string // "define", "include", "ifdef"...
+	Args   string
+}
+
+// parseDirective splits a line such as "  #  define NAME 42" into its
+// parts. ok is false when the line is not a directive.
+func parseDirective(line string) (Directive, bool) {
+	trimmed := strings.TrimLeft(line, " \t")
+	if !strings.HasPrefix(trimmed, "#") {
+		return Directive{}, false
+	}
+	body := strings.TrimLeft(trimmed[1:], " \t")
+
+	end := 0
+	for end < len(body) && isIdentChar(body[end]) {
+		end++
+	}
+	return Directive{
+		Prefix: line[:len(line)-len(body)],
+		Name:   body[:end],
+		Args:   strings.TrimSpace(body[end:]),
+	}, true
+}
+
+// Indent returns the columns of whitespace around the '#' of a directive,
+// tabs advancing to the next multiple of tabWidth.
+func (d Directive) Indent(tabWidth int) int {
+	return displayWidth(d.Prefix, tabWidth) - 1
+}
+
+// macroName returns the name defined by the arguments of a #define.
+func macroName(args string) string {
+	end := 0
+	for end < len(args) && isIdentChar(args[end]) {
+		end++
+	}
+	return args[:end]
+}
+
+// checkPreprocessor validates the indentation of directives nested in
+// conditional blocks and the placement of #include directives.
+func checkPreprocessor(analysis *FileAnalysis, filename string, lineNum int) []Violation {
+	var violations []Violation
+	report := func(line int, id string, args ...any) {
+		violations = append(violations, Violation{
+			Rule:      "C-G3",
+			MessageID: id,
+			Args:      args,
+			Line:      line,
+			Severity:  SeverityMinor,
+		})
+	}
+
+	firstCode := -1
+	if len(analysis.Code) > 0 {
+		firstCode = analysis.Code[0].Offset
+	}
+
+	// Indentation of each open conditional directive
+	var open []int
+	for _, tok := range analysis.Tokens {
+		if tok.Kind != TokPreproc {
+			continue
+		}
+		line := analysis.Lines[tok.Line-1]
+		directive, ok := parseDirective(line)
+		if !ok {
+			continue
+		}
+
+		indent := directive.Indent(analysis.Config.GetTabWidth())
+		switch directive.Name {
+		case "if", "ifdef", "ifndef":
+			checkDirectiveIndent(directive.Name, indent, open, tok.Line, report)
+			open = append(open, indent)
+			continue
+		case "elif", "else", "endif":
+			if len(open) == 0 {
+				report(tok.Line, "unbalanced-directive", directive.Name)
+				continue
+			}
+			if indent != open[len(open)-1] {
+				report(tok.Line, "misaligned-directive", directive.Name)
+			}
+			if directive.Name == "endif" {
+				open = open[:len(open)-1]
+			}
+			continue
+		}
+
+		checkDirectiveIndent(directive.Name, indent, open, tok.Line, report)
+		if directive.Name != "include" {
+			continue
+		}
+		if firstCode >= 0 && tok.Offset > firstCode {
+			report(tok.Line, "misplaced-include")
+		}
+		target := strings.Trim(directive.Args, "<>\" \t")
+		if strings.HasSuffix(target, ".c") {
+			report(tok.Line, "included-source-file", target)
+		}
+	}
+	return violations
+}
+
+func checkDirectiveIndent(name string, indent int, open []int, line int, report func(int, string, ...any)) {
+	if len(open) == 0 {
+		if indent > 0 {
+			report(line, "indented-directive", name)
+		}
+		return
+	}
+	if indent <= open[len(open)-1] {
+		report(line, "unindented-directive", name)
+	}
+}
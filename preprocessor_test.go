@@ -0,0 +1,71 @@
+// preprocessor_test.go
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDirective(t *testing.T) {
+	tests := []struct {
+		line     string
+		tabWidth int
+		name     string
+		args     string
+		indent   int
+	}{
+		{"#define A 1", 8, "define", "A 1", 0},
+		{"#  define A", 8, "define", "A", 2},
+		{"  #define A", 8, "define", "A", 2},
+		{"\t#define A", 8, "define", "A", 8},
+		{"\t#define A", 4, "define", "A", 4},
+		{"#\tdefine A", 8, "define", "A", 7},
+		{"  #\tinclude <a.h>", 4, "include", "<a.h>", 3},
+		{"#", 8, "", "", 0},
+	}
+	for _, tt := range tests {
+		directive, ok := parseDirective(tt.line)
+		if !ok {
+			t.Errorf("parseDirective(%q) is not a directive", tt.line)
+			continue
+		}
+		if directive.Name != tt.name || directive.Args != tt.args {
+			t.Errorf("parseDirective(%q) = %q %q, want %q %q", tt.line, directive.Name, directive.Args, tt.name, tt.args)
+		}
+		if got := directive.Indent(tt.tabWidth); got != tt.indent {
+			t.Errorf("Indent(%q, %d) = %d, want %d", tt.line, tt.tabWidth, got, tt.indent)
+		}
+	}
+	if _, ok := parseDirective("int a; # not a directive"); ok {
+		t.Error("parseDirective accepted a line not starting with '#'")
+	}
+}
+
+func TestCheckPreprocessor(t *testing.T) {
+	source := "#ifndef MY_H\n" +
+		"    #define MY_H\n" +
+		"#define BAD\n" +
+		"    #ifdef DEBUG\n" +
+		"        #include <stdio.h>\n" +
+		"      #endif\n" +
+		"#endif\n" +
+		"  #include <unistd.h>\n" +
+		"#include \"utils.c\"\n" +
+		"#endif\n" +
+		"int a;\n" +
+		"#include <stdlib.h>\n"
+	want := []int{3, 6, 8, 9, 10, 12}
+
+	analysis := NewAnalyzer(2, DefaultConfig()).newFileAnalysis("test.h", []byte(source))
+	var lines []int
+	for _, v := range checkPreprocessor(analysis, "test.h", 0) {
+		lines = append(lines, v.Line)
+		// Every C-G3 violation has the severity the rule is registered with
+		if v.Rule != "C-G3" || v.Severity != SeverityMinor {
+			t.Errorf("line %d: got %s %s, want C-G3 minor", v.Line, v.Rule, v.Severity)
+		}
+	}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("violations on lines %v, want %v", lines, want)
+	}
+}
@@ -0,0 +1,70 @@
+// project.go
+package main
+
+import "strings"
+
+// Project gathers what is known about all the analyzed files, for the
+// rules that need more than the file they are checking.
+type Project struct {
+	// StructTypes holds "struct s" / "union u" for every structure defined
+	// in the sources, along with the typedef names aliasing them.
+	StructTypes map[string]bool
+	// Functions holds the names of the functions defined in the sources,
+	// prototypes left out, Macros the names of the macros they define.
+	Functions map[string]bool
+	Macros    map[string]bool
+}
+
+func NewProject(analyses []*FileAnalysis) *Project {
+	project := &Project{
+		StructTypes: make(map[string]bool),
+		Functions:   make(map[string]bool),
+		Macros:      make(map[string]bool),
+	}
+	typedefs := make(map[string]string)
+
+	for _, analysis := range analyses {
+		for _, fn := range analysis.FunctionDecls {
+			if !fn.Prototype {
+				project.Functions[fn.Name.Text] = true
+			}
+		}
+		for _, tok := range analysis.Tokens {
+			if directive, ok := parseDirective(tok.Text); ok && tok.Kind == TokPreproc && directive.Name == "define" {
+				project.Macros[macroName(directive.Args)] = true
+			}
+		}
+		for _, decl := range analysis.Declarations {
+			switch {
+			case decl.Kind == DeclTag && isRecordType(decl.TypeName):
+				project.StructTypes[decl.TypeName] = true
+			case decl.Kind == DeclTypedef && !decl.Pointer && isRecordType(decl.TypeName):
+				typedefs[decl.Name.Text] = decl.TypeName
+			}
+		}
+	}
+
+	for name, typeName := range typedefs {
+		// Anonymous structures are always defined where they are aliased
+		if project.StructTypes[typeName] || !strings.Contains(typeName, " ") {
+			project.StructTypes[name] = true
+		}
+	}
+	return project
+}
+
+// IsStructType reports whether typeName names a structure or union
+// defined in the analyzed sources.
+func (p *Project) IsStructType(typeName string) bool {
+	return p != nil && p.StructTypes[typeName]
+}
+
+// Defines reports whether name is a function or a macro of the sources.
+func (p *Project) Defines(name string) bool {
+	return p != nil && (p.Functions[name] || p.Macros[name])
+}
+
+func isRecordType(typeName string) bool {
+	return typeName == "struct" || typeName == "union" ||
+		strings.HasPrefix(typeName, "struct ") || strings.HasPrefix(typeName, "union ")
+}
@@ -0,0 +1,160 @@
+// scoring.go
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+const DefaultScoring = "default"
+
+// ScoringModel turns the violations of the files into scores. Penalties
+// are given per severity and may be overridden per rule in the
+// configuration with "weight".
+type ScoringModel struct {
+	Name        string
+	Description string
+	Penalties   map[Severity]float64
+	Percent     bool       // scores are percentages, displayed with a bar
+	Thresholds  [3]float64 // lowest excellent, very good and correct scores
+
+	// File computes the score of a file from its total penalty
+	File func(penalty float64, lines int) float64
+	// Total combines the scores of the files into the global score
+	Total func(files []FileResult) float64
+}
+
+var scoringModels = map[string]ScoringModel{
+	"default": {
+		Name:        "default",
+		Description: "100 minus 5 per major and 2 per minor violation, files averaged",
+		Penalties:   map[Severity]float64{SeverityMajor: 5, SeverityMinor: 2, SeverityInfo: 0},
+		Percent:     true,
+		Thresholds:  [3]float64{90, 75, 50},
+		File:        percentScore,
+		Total:       averageScore,
+	},
+	"weighted": {
+		Name:        "weighted",
+		Description: "100 minus 5 per major and 2 per minor violation, files weighted by line count",
+		Penalties:   map[Severity]float64{SeverityMajor: 5, SeverityMinor: 2, SeverityInfo: 0},
+		Percent:     true,
+		Thresholds:  [3]float64{90, 75, 50},
+		File:        percentScore,
+		Total:       weightedScore,
+	},
+	"density": {
+		Name:        "density",
+		Description: "100 minus the penalties per 100 lines, files weighted by line count",
+		Penalties:   map[Severity]float64{SeverityMajor: 5, SeverityMinor: 2, SeverityInfo: 0},
+		Percent:     true,
+		Thresholds:  [3]float64{90, 75, 50},
+		File: func(penalty float64, lines int) float64 {
+			return percentScore(penalty*100/float64(max(lines, 1)), lines)
+		},
+		Total: weightedScore,
+	},
+	"epitech": {
+		Name:        "epitech",
+		Description: "Official mark: -1 per major, -0.5 per minor and 0 per info violation, summed",
+		Penalties:   map[Severity]float64{SeverityMajor: 1, SeverityMinor: 0.5, SeverityInfo: 0},
+		Thresholds:  [3]float64{0, -2, -5},
+		File: func(penalty float64, lines int) float64 {
+			return -penalty
+		},
+		Total: func(files []FileResult) float64 {
+			total := 0.0
+			for _, file := range files {
+				total += file.Score
+			}
+			return total
+		},
+	},
+}
+
+// scoringModel returns the model with the given name, the default one
+// when name is empty.
+func scoringModel(name string) (ScoringModel, error) {
+	if name == "" {
+		name = DefaultScoring
+	}
+	model, ok := scoringModels[name]
+	if !ok {
+		return ScoringModel{}, fmt.Errorf("unknown scoring model '%s' (expected %s)", name, scoringNames())
+	}
+	return model, nil
+}
+
+func scoringNames() string {
+	var names []string
+	for name := range scoringModels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// Score computes the score of a file.
+func (m ScoringModel) Score(violations []Violation, lines int, config *Config) float64 {
+	penalty := 0.0
+	for _, v := range violations {
+		if weight, ok := config.Weight(v.Rule); ok {
+			penalty += weight
+		} else {
+			penalty += m.Penalties[v.Severity]
+		}
+	}
+	return m.File(penalty, lines)
+}
+
+// Format formats a score for display.
+func (m ScoringModel) Format(score float64) string {
+	if m.Percent {
+		return fmt.Sprintf("%.1f%%", score)
+	}
+	return fmt.Sprintf("%g", score)
+}
+
+// Rank returns 0 for an excellent score, 1 for a very good one, 2 for a
+// correct one and 3 otherwise.
+func (m ScoringModel) Rank(score float64) int {
+	for rank, threshold := range m.Thresholds {
+		if score >= threshold {
+			return rank
+		}
+	}
+	return len(m.Thresholds)
+}
+
+// percentScore removes the penalty from 100, clamping at zero.
+func percentScore(penalty float64, lines int) float64 {
+	return math.Max(0, 100-penalty)
+}
+
+func averageScore(files []FileResult) float64 {
+	if len(files) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, file := range files {
+		total += file.Score
+	}
+	return total / float64(len(files))
+}
+
+// weightedScore averages the file scores by line count. Files without
+// lines, such as delivery artifacts, count as one line.
+func weightedScore(files []FileResult) float64 {
+	total, weights := 0.0, 0.0
+	for _, file := range files {
+		weight := float64(max(file.LineCount, 1))
+		total += file.Score * weight
+		weights += weight
+	}
+	if weights == 0 {
+		return 0
+	}
+	return total / weights
+}
@@ -0,0 +1,120 @@
+// scoring_test.go
+package main
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestScoringModels(t *testing.T) {
+	major := Violation{Rule: "C-F3", Severity: SeverityMajor}
+	minor := Violation{Rule: "C-L4", Severity: SeverityMinor}
+	info := Violation{Rule: "C-F10", Severity: SeverityInfo}
+	weighted := Violation{Rule: "C-G2", Severity: SeverityMinor}
+
+	// Three files: 100 lines with 2 major and 1 minor violations, 20 lines
+	// with an info one, and 10 lines with 3 C-G2 violations weighted 0.5
+	files := []struct {
+		lines      int
+		violations []Violation
+	}{
+		{100, []Violation{major, major, minor}},
+		{20, []Violation{info}},
+		{10, []Violation{weighted, weighted, weighted}},
+	}
+	weight := 0.5
+	config := DefaultConfig()
+	config.Rules["C-G2"] = RuleConfig{Weight: &weight}
+
+	tests := []struct {
+		model  string
+		scores []float64
+		total  float64
+	}{
+		{"default", []float64{88, 100, 98.5}, (88 + 100 + 98.5) / 3},
+		{"weighted", []float64{88, 100, 98.5}, (88*100 + 100*20 + 98.5*10) / 130},
+		{"density", []float64{88, 100, 85}, (88*100 + 100*20 + 85*10) / 130.0},
+		{"epitech", []float64{-2.5, 0, -1.5}, -4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			model, err := scoringModel(tt.model)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var results []FileResult
+			var scores []float64
+			for _, file := range files {
+				score := model.Score(file.violations, file.lines, config)
+				scores = append(scores, score)
+				results = append(results, FileResult{Score: score, LineCount: file.lines})
+			}
+			if !reflect.DeepEqual(scores, tt.scores) {
+				t.Errorf("file scores %v, want %v", scores, tt.scores)
+			}
+			if total := model.Total(results); math.Abs(total-tt.total) > 1e-9 {
+				t.Errorf("Total() = %v, want %v", total, tt.total)
+			}
+		})
+	}
+}
+
+func TestScoringBounds(t *testing.T) {
+	many := make([]Violation, 30)
+	for i := range many {
+		many[i] = Violation{Rule: "C-F3", Severity: SeverityMajor}
+	}
+	tests := []struct {
+		model string
+		lines int
+		want  float64
+	}{
+		{"default", 10, 0},
+		{"density", 1000, 85},
+		{"density", 0, 0},
+		{"epitech", 10, -30},
+	}
+	for _, tt := range tests {
+		model, _ := scoringModel(tt.model)
+		if got := model.Score(many, tt.lines, DefaultConfig()); got != tt.want {
+			t.Errorf("%s: Score(30 major, %d lines) = %v, want %v", tt.model, tt.lines, got, tt.want)
+		}
+	}
+
+	for _, model := range scoringModels {
+		if total := model.Total(nil); total != 0 {
+			t.Errorf("%s: Total(nil) = %v, want 0", model.Name, total)
+		}
+	}
+	if _, err := scoringModel("strict"); err == nil {
+		t.Error("scoringModel(strict) succeeded, want an error")
+	}
+	if model, err := scoringModel(""); err != nil || model.Name != DefaultScoring {
+		t.Errorf("scoringModel(\"\") = %s, %v, want the default model", model.Name, err)
+	}
+}
+
+func TestScoringRank(t *testing.T) {
+	tests := []struct {
+		model string
+		score float64
+		want  int
+	}{
+		{"default", 95, 0},
+		{"default", 90, 0},
+		{"default", 80, 1},
+		{"default", 60, 2},
+		{"default", 10, 3},
+		{"epitech", 0, 0},
+		{"epitech", -1.5, 1},
+		{"epitech", -4, 2},
+		{"epitech", -12, 3},
+	}
+	for _, tt := range tests {
+		model, _ := scoringModel(tt.model)
+		if got := model.Rank(tt.score); got != tt.want {
+			t.Errorf("%s: Rank(%v) = %d, want %d", tt.model, tt.score, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// severity.go
+package main
+
+import "fmt"
+
+// Severity ranks violations. It is written as "info", "minor" or "major"
+// in JSON reports and configuration files.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityMinor
+	SeverityMajor
+)
+
+var severityNames = map[Severity]string{
+	SeverityInfo:  "info",
+	SeverityMinor: "minor",
+	SeverityMajor: "major",
+}
+
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("severity(%d)", int(s))
+}
+
+// ParseSeverity parses the name of a severity.
+func ParseSeverity(name string) (Severity, error) {
+	for severity, severityName := range severityNames {
+		if name == severityName {
+			return severity, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown severity '%s' (expected major, minor or info)", name)
+}
+
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *Severity) UnmarshalText(text []byte) error {
+	severity, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+	*s = severity
+	return nil
+}
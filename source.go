@@ -0,0 +1,117 @@
+// source.go
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// FileError records a file that could not be analyzed. Internal is set
+// when the file is fine but a rule failed on it.
+type FileError struct {
+	Filename string `json:"filename"`
+	Error    string `json:"error"`
+	Internal bool   `json:"internal,omitempty"`
+}
+
+// RuleError is the failure of a rule on a file, a bug of epicstyle.
+type RuleError struct {
+	Rule  string
+	Panic any
+}
+
+func (e *RuleError) Error() string {
+	return fmt.Sprintf("internal error in rule %s: %v", e.Rule, e.Panic)
+}
+
+// newFileError describes err without repeating the filename, which file
+// system errors include.
+func newFileError(filename string, err error) FileError {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return FileError{Filename: filename, Error: pathErr.Op + ": " + pathErr.Err.Error()}
+	}
+	var ruleErr *RuleError
+	return FileError{Filename: filename, Error: err.Error(), Internal: errors.As(err, &ruleErr)}
+}
+
+// readSource reads a C source file, rejecting binary and non-UTF-8 content
+// that the rules cannot make sense of.
+func readSource(filename string) ([]byte, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSource(content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// checkSource rejects binary and non-UTF-8 content.
+func checkSource(content []byte) error {
+	if bytes.IndexByte(content, 0) >= 0 {
+		return errors.New("binary file")
+	}
+	if !utf8.Valid(content) {
+		line := bytes.Count(content[:invalidUTF8Offset(content)], []byte("\n")) + 1
+		return fmt.Errorf("line %d: invalid UTF-8", line)
+	}
+	return nil
+}
+
+// isSourceFile reports whether a file name has a C source extension.
+func isSourceFile(filename string) bool {
+	return strings.HasSuffix(filename, ".c") || strings.HasSuffix(filename, ".h")
+}
+
+// readPathListFile reads the path list of a file, or of stdin for "-".
+func readPathListFile(filename string) ([]string, error) {
+	if filename == "-" {
+		return readPathList(os.Stdin)
+	}
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return readPathList(file)
+}
+
+// readPathList reads the paths listed one per line, as printed by
+// "git ls-files" or "find". Entries other than C sources and directories
+// are dropped so that a whole repository listing can be passed.
+func readPathList(r io.Reader) ([]string, error) {
+	var paths []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		path := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(path) == "" {
+			continue
+		}
+		if info, err := os.Stat(path); isSourceFile(path) || err == nil && info.IsDir() {
+			paths = append(paths, path)
+		}
+	}
+	return paths, scanner.Err()
+}
+
+// invalidUTF8Offset returns the offset of the first invalid UTF-8 sequence.
+func invalidUTF8Offset(content []byte) int {
+	offset := 0
+	for offset < len(content) {
+		r, size := utf8.DecodeRune(content[offset:])
+		if r == utf8.RuneError && size == 1 {
+			return offset
+		}
+		offset += size
+	}
+	return offset
+}
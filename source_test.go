@@ -0,0 +1,57 @@
+// source_test.go
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadSource(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{"text", "int main(void);\n", ""},
+		{"utf-8", "// é ☃\n", ""},
+		{"binary", "\x7fELF\x00\x01", "binary file"},
+		{"latin-1", "int a;\n// \xe9t\xe9\n", "line 2: invalid UTF-8"},
+	}
+	dir := t.TempDir()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name+".c")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			content, err := readSource(path)
+			if tt.err == "" {
+				if err != nil || string(content) != tt.content {
+					t.Errorf("readSource() = %q, %v, want the content", content, err)
+				}
+			} else if err == nil || err.Error() != tt.err {
+				t.Errorf("readSource() error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+
+	_, err := readSource(filepath.Join(dir, "missing.c"))
+	if fileErr := newFileError("missing.c", err); fileErr.Error != "open: no such file or directory" || fileErr.Internal {
+		t.Errorf("newFileError() = %+v", fileErr)
+	}
+}
+
+func TestAnalyzePathNotASource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(path, []byte("todo\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	report := NewAnalyzer(1, DefaultConfig()).AnalyzePaths([]string{path})
+	if len(report.Files) != 0 || len(report.Errors) != 1 || report.Errors[0].Filename != path {
+		t.Errorf("got files %+v and errors %+v, want an error for %s", report.Files, report.Errors, path)
+	}
+	if code := exitCode(report, FailPolicy{FailOn: SeverityMinor}); code != ExitIOError {
+		t.Errorf("exitCode() = %d, want %d", code, ExitIOError)
+	}
+}
@@ -0,0 +1,207 @@
+// spacing.go
+package main
+
+import "strings"
+
+// Keywords that must be followed by a space when not ending the statement
+var spacedKeywords = map[string]bool{
+	"if": true, "while": true, "for": true, "return": true, "switch": true,
+}
+
+// Operators that always take a space on both sides
+var binaryOperators = map[string]bool{
+	"=": true, "+=": true, "-=": true, "*=": true, "/=": true, "%=": true,
+	"&=": true, "|=": true, "^=": true, "<<=": true, ">>=": true,
+	"==": true, "!=": true, "<": true, ">": true, "<=": true, ">=": true,
+	"&&": true, "||": true, "<<": true, ">>": true,
+	"/": true, "%": true, "|": true, "^": true,
+}
+
+// Operators that are binary only when they follow an operand
+var ambiguousOperators = map[string]bool{
+	"+": true, "-": true, "*": true, "&": true,
+}
+
+var typeKeywords = map[string]bool{
+	"char": true, "short": true, "int": true, "long": true, "float": true,
+	"double": true, "void": true, "signed": true, "unsigned": true,
+	"_Bool": true, "const": true, "volatile": true, "restrict": true,
+}
+
+// Tokens after which an identifier starts a new declaration
+var declarationStarters = map[string]bool{
+	";": true, "{": true, "}": true, "(": true, ",": true,
+	"static": true, "const": true, "extern": true, "register": true,
+	"volatile": true, "inline": true, "typedef": true,
+}
+
+func checkSpacing(analysis *FileAnalysis, filename string, lineNum int) []Violation {
+	var violations []Violation
+	code := analysis.Code
+
+	report := func(tok Token, id string, args ...any) {
+		violations = append(violations, atToken(Violation{
+			Rule:      "C-L6",
+			MessageID: id,
+			Args:      args,
+			Line:      tok.Line,
+			Severity:  SeverityMinor,
+		}, tok))
+	}
+
+	for i, tok := range code {
+		var prev, next *Token
+		if i > 0 {
+			prev = &code[i-1]
+		}
+		if i+1 < len(code) {
+			next = &code[i+1]
+		}
+		spaceBefore := prev != nil && tok.Offset > prev.End()
+		spaceAfter := next != nil && next.Offset > tok.End()
+		sameLineBefore := prev != nil && prev.Line == tok.Line
+		sameLineAfter := next != nil && next.Line == tok.Line
+
+		switch {
+		case tok.Kind == TokKeyword && spacedKeywords[tok.Text]:
+			if next != nil && !spaceAfter && next.Text != ";" {
+				report(tok, "missing-space-after-keyword", tok.Text)
+			}
+		case tok.Kind != TokPunct:
+			continue
+		case tok.Text == ",":
+			if next != nil && !spaceAfter {
+				report(tok, "missing-space-after-comma")
+			}
+		case tok.Text == ";":
+			if spaceBefore && sameLineBefore && prev.Text != ";" && prev.Text != "(" {
+				report(tok, "space-before-semicolon")
+			}
+		case tok.Text == "(":
+			if spaceAfter && sameLineAfter && next.Text != ";" {
+				report(tok, "space-after-parenthesis")
+			}
+		case tok.Text == ")":
+			if spaceBefore && sameLineBefore && prev.Text != ";" {
+				report(tok, "space-before-parenthesis")
+			}
+		case binaryOperators[tok.Text] || (ambiguousOperators[tok.Text] && isBinaryOperator(code, i)):
+			if prev != nil && next != nil && (!spaceBefore || !spaceAfter) {
+				report(tok, "missing-space-around-operator", tok.Text)
+			}
+		}
+	}
+	return violations
+}
+
+// isBinaryOperator tells whether the +, -, * or & at index i is used as a
+// binary operator rather than a unary one or a pointer declarator.
+func isBinaryOperator(code []Token, i int) bool {
+	if i == 0 || !isOperandEnd(code, i-1) {
+		return false
+	}
+	if code[i].Text != "*" {
+		return true
+	}
+	return !isPointerDeclarator(code, i)
+}
+
+// isOperandEnd reports whether the token at index i can end an expression.
+func isOperandEnd(code []Token, i int) bool {
+	tok := code[i]
+	switch tok.Kind {
+	case TokIdent, TokNumber, TokString, TokChar:
+		return true
+	case TokPunct:
+		if tok.Text == "]" {
+			return true
+		}
+		return tok.Text == ")" && !isCastClose(code, i)
+	}
+	return false
+}
+
+// isCastClose reports whether the ')' at index i closes a cast like (char *).
+func isCastClose(code []Token, i int) bool {
+	open := matchingOpen(code, i)
+	if open < 0 || open == i-1 {
+		return false
+	}
+	if open > 0 {
+		before := code[open-1]
+		if before.Kind == TokIdent || before.Kind == TokKeyword || before.Text == ")" || before.Text == "]" {
+			if before.Text != "return" && before.Text != "case" {
+				return false
+			}
+		}
+	}
+	typed := false
+	for k := open + 1; k < i; k++ {
+		t := code[k]
+		switch {
+		case t.Text == "*":
+		case t.Kind == TokKeyword && (typeKeywords[t.Text] || isTagKeyword(t.Text)):
+			typed = true
+		case t.Kind == TokIdent:
+			if strings.HasSuffix(t.Text, "_t") || (k > open+1 && isTagKeyword(code[k-1].Text)) {
+				typed = true
+			}
+		default:
+			return false
+		}
+	}
+	return typed
+}
+
+func isTagKeyword(s string) bool {
+	return s == "struct" || s == "union" || s == "enum"
+}
+
+// matchingOpen returns the index of the '(' matching the ')' at index i.
+func matchingOpen(code []Token, i int) int {
+	depth := 0
+	for k := i; k >= 0; k-- {
+		switch code[k].Text {
+		case ")":
+			depth++
+		case "(":
+			depth--
+			if depth == 0 {
+				return k
+			}
+		}
+	}
+	return -1
+}
+
+func isPointerDeclarator(code []Token, i int) bool {
+	prev := code[i-1]
+	if prev.Kind == TokKeyword && typeKeywords[prev.Text] {
+		return true
+	}
+	if i+1 < len(code) {
+		switch code[i+1].Text {
+		case "*", ")", ",":
+			return true
+		}
+	}
+	if prev.Kind != TokIdent {
+		return false
+	}
+	if strings.HasSuffix(prev.Text, "_t") {
+		return true
+	}
+	if i >= 2 {
+		before := code[i-2]
+		if isTagKeyword(before.Text) {
+			return true
+		}
+		if !declarationStarters[before.Text] {
+			return false
+		}
+	}
+	// "type *name": spaced before, glued to the declared name
+	spaced := code[i].Offset > prev.End()
+	glued := i+1 < len(code) && code[i+1].Offset == code[i].End()
+	return spaced && glued
+}
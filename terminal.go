@@ -0,0 +1,158 @@
+// terminal.go
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// Width of the boxes of the terminal report, borders included
+const boxWidth = 80
+
+// symbolSet holds the characters drawn by the terminal report.
+type symbolSet struct {
+	Horizontal, Vertical                             string
+	TopLeft, TopRight, BottomLeft, BottomRight       string
+	Bullet, Filled, Empty                            string
+	Summary, Clean, Dirty, Warning, Error, Excellent string
+}
+
+var unicodeSymbols = symbolSet{
+	Horizontal: "═", Vertical: "║",
+	TopLeft: "╔", TopRight: "╗", BottomLeft: "╚", BottomRight: "╝",
+	Bullet: "•", Filled: "█", Empty: "░",
+	Summary: "📊 ", Clean: "✅ ", Dirty: "❌ ", Warning: "⚠️ ", Error: "❗ ", Excellent: "🎉 ",
+}
+
+// asciiSymbols replace the box drawing characters and emoji for terminals
+// and logs that cannot display them.
+var asciiSymbols = symbolSet{
+	Horizontal: "=", Vertical: "|",
+	TopLeft: "+", TopRight: "+", BottomLeft: "+", BottomRight: "+",
+	Bullet: "-", Filled: "#", Empty: ".",
+	Summary: "", Clean: "[OK] ", Dirty: "[KO] ", Warning: "/!\\ ", Error: "[!] ", Excellent: "",
+}
+
+// Symbols of the terminal report
+var symbols = unicodeSymbols
+
+// SetASCII restricts the terminal report to ASCII symbols.
+func SetASCII(ascii bool) {
+	symbols = unicodeSymbols
+	if ascii {
+		symbols = asciiSymbols
+	}
+}
+
+// detectASCII tells whether the terminal is unlikely to display the Unicode
+// report: the locale of LC_ALL, LC_CTYPE or LANG, in the order of
+// precedence of POSIX, is "C", "POSIX" or has a charset other than UTF-8,
+// or TERM is "dumb" or the Linux console, which has no emoji. An unset
+// locale keeps Unicode, the charset of most terminals.
+func detectASCII() bool {
+	switch os.Getenv("TERM") {
+	case "dumb", "linux":
+		return true
+	}
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		locale := os.Getenv(name)
+		if locale == "" {
+			continue
+		}
+		if locale == "C" || locale == "POSIX" {
+			return true
+		}
+		_, charset, ok := strings.Cut(locale, ".")
+		charset, _, _ = strings.Cut(charset, "@")
+		charset = strings.ReplaceAll(strings.ToLower(charset), "-", "")
+		return ok && charset != "utf8"
+	}
+	return false
+}
+
+// SetColor enables or disables the colors of the terminal output. Mode
+// "auto" enables them when out is a terminal, unless NO_COLOR is set or
+// TERM is "dumb".
+func SetColor(mode string, out *os.File) error {
+	var enabled bool
+	switch mode {
+	case "always":
+		enabled = true
+	case "never":
+		enabled = false
+	case "auto", "":
+		enabled = os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb" && isTerminal(out)
+	default:
+		return fmt.Errorf("unknown color mode '%s' (available: auto, always, never)", mode)
+	}
+
+	ColorReset, ColorBold = "", ""
+	ColorRed, ColorGreen, ColorYellow, ColorBlue = "", "", "", ""
+	ColorPurple, ColorCyan, ColorWhite = "", "", ""
+	if enabled {
+		ColorReset, ColorBold = "\033[0m", "\033[1m"
+		ColorRed, ColorGreen, ColorYellow, ColorBlue = "\033[31m", "\033[32m", "\033[33m", "\033[34m"
+		ColorPurple, ColorCyan, ColorWhite = "\033[35m", "\033[36m", "\033[37m"
+	}
+	return nil
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// boxBorder returns the top or bottom border of a box.
+func boxBorder(top bool) string {
+	left, right := symbols.BottomLeft, symbols.BottomRight
+	if top {
+		left, right = symbols.TopLeft, symbols.TopRight
+	}
+	return ColorBold + left + strings.Repeat(symbols.Horizontal, boxWidth-2) + right + ColorReset
+}
+
+// boxLine returns text centered between the sides of a box. Text may hold
+// colors, which take no room on screen.
+func boxLine(text string) string {
+	padding := max(boxWidth-2-termWidth(text), 0)
+	return ColorBold + symbols.Vertical + ColorReset + strings.Repeat(" ", padding/2) + text +
+		strings.Repeat(" ", padding-padding/2) + ColorBold + symbols.Vertical + ColorReset
+}
+
+// termWidth returns the number of columns text takes in a terminal: escape
+// sequences take none, emoji and East Asian wide characters take two.
+func termWidth(text string) int {
+	width := 0
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\033':
+			// Skip a CSI sequence up to its final letter
+			for i++; i < len(runes) && !unicode.IsLetter(runes[i]); i++ {
+			}
+		case r == '\u200d' || r == '\ufe0f' || unicode.Is(unicode.Mn, r):
+			// Joiners, variation selectors and combining marks take no room
+		case isWide(r) || i+1 < len(runes) && runes[i+1] == '\ufe0f':
+			width += 2
+		default:
+			width++
+		}
+	}
+	return width
+}
+
+// isWide tells whether a character is drawn on two columns.
+func isWide(r rune) bool {
+	switch {
+	case r == '✅' || r == '❌' || r == '❗':
+		return true
+	case r >= 0x1100 && r <= 0x115f, r >= 0x2e80 && r <= 0xa4cf, r >= 0xac00 && r <= 0xd7a3,
+		r >= 0xf900 && r <= 0xfaff, r >= 0xfe30 && r <= 0xfe4f, r >= 0xff00 && r <= 0xff60,
+		r >= 0xffe0 && r <= 0xffe6, r >= 0x1f000 && r <= 0x1faff, r >= 0x20000 && r <= 0x3fffd:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+// terminal_test.go
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectASCII(t *testing.T) {
+	tests := []struct {
+		term, lcAll, lcCtype, lang string
+		want                       bool
+	}{
+		{"xterm-256color", "", "", "fr_FR.UTF-8", false},
+		{"xterm-256color", "", "", "en_US.utf8", false},
+		{"xterm-256color", "", "", "", false},
+		{"xterm-256color", "", "", "C", true},
+		{"xterm-256color", "", "", "POSIX", true},
+		{"xterm-256color", "", "", "fr_FR.ISO-8859-1", true},
+		{"xterm-256color", "", "", "de_DE.UTF-8@euro", false},
+		{"xterm-256color", "C", "", "fr_FR.UTF-8", true},
+		{"xterm-256color", "", "en_US.UTF-8", "C", false},
+		{"dumb", "", "", "fr_FR.UTF-8", true},
+		{"linux", "", "", "fr_FR.UTF-8", true},
+	}
+	for _, tt := range tests {
+		t.Setenv("TERM", tt.term)
+		t.Setenv("LC_ALL", tt.lcAll)
+		t.Setenv("LC_CTYPE", tt.lcCtype)
+		t.Setenv("LANG", tt.lang)
+		if got := detectASCII(); got != tt.want {
+			t.Errorf("TERM=%s LC_ALL=%s LC_CTYPE=%s LANG=%s: detectASCII() = %v, want %v",
+				tt.term, tt.lcAll, tt.lcCtype, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestTermWidth(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"score", 5},
+		{"Note : 78,5 %", 13},
+		{"\033[1m\033[32mok\033[0m", 2},
+		{"✅ main.c", 9},
+		{"⚠️ attention", 12},
+		{"日本語", 6},
+		{"é", 1},
+		{"e\u0301", 1},
+	}
+	for _, tt := range tests {
+		if got := termWidth(tt.text); got != tt.want {
+			t.Errorf("termWidth(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestBoxLine(t *testing.T) {
+	defer SetASCII(false)
+	for _, ascii := range []bool{false, true} {
+		SetASCII(ascii)
+		for _, text := range []string{"", "EPICSTYLE", "🎉 Excellent", "コードの品質", ColorGreen + "✅ 100%" + ColorReset} {
+			line := boxLine(text)
+			if got := termWidth(line); got != boxWidth {
+				t.Errorf("ascii %v: boxLine(%q) is %d columns wide, want %d", ascii, text, got, boxWidth)
+			}
+			if !strings.HasPrefix(line, ColorBold+symbols.Vertical) || !strings.HasSuffix(line, symbols.Vertical+ColorReset) {
+				t.Errorf("ascii %v: boxLine(%q) = %q, want it between the sides of the box", ascii, text, line)
+			}
+		}
+		if got := termWidth(boxBorder(true)); got != boxWidth {
+			t.Errorf("ascii %v: border is %d columns wide, want %d", ascii, got, boxWidth)
+		}
+	}
+}
@@ -0,0 +1,504 @@
+// tui.go
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Escape sequences of the full-screen interface
+const (
+	tuiEnterScreen = "\033[?1049h\033[?25l"
+	tuiLeaveScreen = "\033[?25h\033[?1049l"
+	tuiHome        = "\033[H"
+	tuiClearLine   = "\033[K"
+	tuiReverse     = "\033[7m"
+	tuiReset       = "\033[0m"
+)
+
+// Keys decoded from the terminal input
+const (
+	keyUp = iota + 256
+	keyDown
+	keyLeft
+	keyRight
+	keyPageUp
+	keyPageDown
+	keyEscape
+	keyEnter
+	keyBackspace
+)
+
+// tui browses a report: files sorted by score on the left, violations of
+// the selected file on the right above a preview of its source.
+type tui struct {
+	report  *Report
+	reload  func() *Report
+	files   []FileResult
+	model   ScoringModel
+	width   int
+	height  int
+	focus   int  // 0 for the file list, 1 for the violations
+	byName  bool // files in name order instead of worst score first
+	file    int
+	fileTop int
+	item    int
+	itemTop int
+
+	severity Severity
+	allLevel bool   // no severity filter
+	rule     string // rule code prefix filter
+	input    *string
+	status   string
+}
+
+// runTUI shows the report until the user quits and returns the last one.
+// reload analyzes the sources again, after they have been edited.
+func runTUI(report *Report, reload func() *Report) (*Report, error) {
+	// Raw mode goes through stty, there is no fallback without it. stty
+	// also fails on character devices that are no terminal, like /dev/null.
+	notTerminal := errors.New("tui: stdin is not a terminal, run it interactively or use the text or -json report")
+	if info, err := os.Stdin.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return nil, notTerminal
+	}
+	if _, err := exec.LookPath("stty"); err != nil {
+		return nil, errors.New("tui: stty not found, the interface needs a Unix terminal; use the text or -json report")
+	}
+	saved, err := stty("-g")
+	if err != nil {
+		return nil, notTerminal
+	}
+	saved = strings.TrimSpace(saved)
+	if _, err := stty("raw", "-echo"); err != nil {
+		return nil, fmt.Errorf("tui: cannot configure the terminal: %v", err)
+	}
+	defer func() {
+		fmt.Print(tuiLeaveScreen)
+		stty(saved)
+	}()
+	fmt.Print(tuiEnterScreen)
+
+	t := &tui{allLevel: true}
+	t.setReport(report)
+	t.reload = reload
+	buf := make([]byte, 64)
+	for {
+		t.resize()
+		t.render()
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return t.report, err
+		}
+		for _, key := range decodeKeys(buf[:n]) {
+			if !t.handle(key, saved) {
+				return t.report, nil
+			}
+		}
+	}
+}
+
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return string(out), err
+}
+
+func (t *tui) setReport(report *Report) {
+	t.report = report
+	t.model, _ = scoringModel(report.Scoring)
+	t.files = append([]FileResult(nil), report.Files...)
+	t.sortFiles()
+	for i := range t.files {
+		sortViolations(t.files[i].Violations)
+	}
+	t.file = min(t.file, max(len(t.files)-1, 0))
+	t.item = 0
+}
+
+// sortFiles orders the files by ascending score, the worst ones first since
+// they are the ones to work on, or by name.
+func (t *tui) sortFiles() {
+	sort.SliceStable(t.files, func(i, j int) bool {
+		if t.byName || t.files[i].Score == t.files[j].Score {
+			return t.files[i].Filename < t.files[j].Filename
+		}
+		return t.files[i].Score < t.files[j].Score
+	})
+}
+
+// sortViolations orders violations by position in the file.
+func sortViolations(violations []Violation) {
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].Line != violations[j].Line {
+			return violations[i].Line < violations[j].Line
+		}
+		return violations[i].Column < violations[j].Column
+	})
+}
+
+func (t *tui) resize() {
+	t.height, t.width = 24, 80
+	if out, err := stty("size"); err == nil {
+		var rows, cols int
+		if _, err := fmt.Sscan(out, &rows, &cols); err == nil && rows > 0 && cols > 0 {
+			t.height, t.width = rows, cols
+		}
+	}
+}
+
+// visible returns the violations of the selected file passing the filters.
+func (t *tui) visible() []Violation {
+	if len(t.files) == 0 {
+		return nil
+	}
+	var violations []Violation
+	for _, v := range t.files[t.file].Violations {
+		if !t.allLevel && v.Severity != t.severity {
+			continue
+		}
+		if t.rule != "" && !strings.HasPrefix(v.Rule, t.rule) {
+			continue
+		}
+		violations = append(violations, v)
+	}
+	return violations
+}
+
+// handle applies a key and reports whether the interface keeps running.
+func (t *tui) handle(key int, saved string) bool {
+	t.status = ""
+	if t.input != nil {
+		switch key {
+		case keyEnter:
+			t.rule = strings.ToUpper(*t.input)
+			t.input = nil
+			t.item, t.itemTop = 0, 0
+		case keyEscape:
+			t.input = nil
+		case keyBackspace:
+			if len(*t.input) > 0 {
+				*t.input = (*t.input)[:len(*t.input)-1]
+			}
+		default:
+			if key >= ' ' && key < 127 {
+				*t.input += string(rune(key))
+			}
+		}
+		return true
+	}
+
+	visible := t.visible()
+	page := max(t.listHeight()-1, 1)
+	switch key {
+	case 'q', 3: // Ctrl-C
+		return false
+	case '\t', keyLeft, keyRight, 'h', 'l':
+		if key == keyLeft || key == 'h' {
+			t.focus = 0
+		} else if key == keyRight || key == 'l' {
+			t.focus = 1
+		} else {
+			t.focus = 1 - t.focus
+		}
+	case keyUp, 'k':
+		t.move(-1, visible)
+	case keyDown, 'j':
+		t.move(1, visible)
+	case keyPageUp:
+		t.move(-page, visible)
+	case keyPageDown:
+		t.move(page, visible)
+	case 's':
+		// Cycle through all, major, minor and info
+		switch {
+		case t.allLevel:
+			t.allLevel, t.severity = false, SeverityMajor
+		case t.severity == SeverityInfo:
+			t.allLevel = true
+		default:
+			t.severity--
+		}
+		t.item, t.itemTop = 0, 0
+	case 'r', '/':
+		input := t.rule
+		t.input = &input
+	case 'c':
+		t.allLevel, t.rule = true, ""
+		t.item, t.itemTop = 0, 0
+	case 'o':
+		t.byName = !t.byName
+		if len(t.files) > 0 {
+			selected := t.files[t.file].Filename
+			t.sortFiles()
+			for i, file := range t.files {
+				if file.Filename == selected {
+					t.file = i
+				}
+			}
+		}
+	case 'e', keyEnter:
+		t.edit(visible, saved)
+	}
+	return true
+}
+
+func (t *tui) move(delta int, visible []Violation) {
+	if t.focus == 0 {
+		t.file = clamp(t.file+delta, 0, len(t.files)-1)
+		t.item, t.itemTop = 0, 0
+	} else {
+		t.item = clamp(t.item+delta, 0, len(visible)-1)
+	}
+}
+
+func clamp(value, low, high int) int {
+	if value > high {
+		value = high
+	}
+	if value < low {
+		value = low
+	}
+	return value
+}
+
+// edit opens the selected violation in $VISUAL or $EDITOR, then analyzes
+// the sources again.
+func (t *tui) edit(visible []Violation, saved string) {
+	if len(t.files) == 0 || t.files[t.file].Path == "" {
+		return
+	}
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	line := 1
+	if t.item < len(visible) {
+		line = max(visible[t.item].Line, 1)
+	}
+
+	args := strings.Fields(editor)
+	args = append(args, "+"+strconv.Itoa(line), t.files[t.file].Path)
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+
+	fmt.Print(tuiLeaveScreen)
+	stty(saved)
+	err := cmd.Run()
+	stty("raw", "-echo")
+	fmt.Print(tuiEnterScreen)
+	if err != nil {
+		t.status = fmt.Sprintf("%s: %v", args[0], err)
+		return
+	}
+
+	path, item := t.files[t.file].Path, t.item
+	t.setReport(t.reload())
+	for i, file := range t.files {
+		if file.Path == path {
+			t.file = i
+		}
+	}
+	t.item = clamp(item, 0, len(t.visible())-1)
+}
+
+func (t *tui) listHeight() int {
+	return max((t.height-2)/2, 1)
+}
+
+func (t *tui) render() {
+	var frame strings.Builder
+	frame.WriteString(tuiHome)
+	line := func(text string) {
+		frame.WriteString(text + tuiClearLine + "\r\n")
+	}
+
+	// Title bar
+	filter := tr("tui.all")
+	if !t.allLevel {
+		filter = t.severity.String()
+	}
+	if t.rule != "" {
+		filter += " " + t.rule + "*"
+	}
+	order := tr("tui.worst-first")
+	if t.byName {
+		order = tr("tui.by-name")
+	}
+	title := tr("tui.title", t.model.Format(t.report.TotalScore), t.report.TotalFiles, t.report.TotalViolations, filter, order)
+	line(tuiReverse + fitText(title, t.width) + tuiReset)
+
+	leftWidth := clamp(t.width/3, 10, 40)
+	rightWidth := max(t.width-leftWidth-1, 1)
+	bodyHeight := max(t.height-2, 1)
+	listHeight := t.listHeight()
+	visible := t.visible()
+	t.fileTop = scrollTo(t.fileTop, t.file, bodyHeight)
+	t.itemTop = scrollTo(t.itemTop, t.item, listHeight)
+
+	preview := t.preview(visible, bodyHeight-listHeight-1, rightWidth)
+	for row := 0; row < bodyHeight; row++ {
+		left := strings.Repeat(" ", leftWidth)
+		if index := t.fileTop + row; index < len(t.files) {
+			left = t.fileRow(index, leftWidth)
+		}
+
+		var right string
+		switch {
+		case row < listHeight:
+			right = t.violationRow(visible, t.itemTop+row, rightWidth)
+		case row == listHeight:
+			right = fitText(strings.Repeat("─", rightWidth), rightWidth)
+		default:
+			right = preview[row-listHeight-1]
+		}
+		line(left + "│" + right)
+	}
+
+	// Status or help bar, without a final newline to keep the screen still
+	help := tr("tui.help")
+	switch {
+	case t.input != nil:
+		help = tr("tui.rule-prompt") + *t.input + "█"
+	case t.status != "":
+		help = " " + t.status
+	}
+	frame.WriteString(tuiReverse + fitText(help, t.width) + tuiReset)
+	fmt.Print(frame.String())
+}
+
+// scrollTo returns the first visible row keeping selected on screen.
+func scrollTo(top, selected, height int) int {
+	if selected < top {
+		return selected
+	}
+	if selected >= top+height {
+		return selected - height + 1
+	}
+	return top
+}
+
+func (t *tui) fileRow(index, width int) string {
+	file := t.files[index]
+	text := fmt.Sprintf(" %s %s (%d)", t.model.Format(file.Score), file.Filename, len(file.Violations))
+	text = fitText(text, width)
+	switch {
+	case index == t.file && t.focus == 0:
+		return tuiReverse + text + tuiReset
+	case index == t.file:
+		return ColorBold + text + tuiReset
+	case len(file.Violations) == 0:
+		return ColorGreen + text + tuiReset
+	}
+	return text
+}
+
+func (t *tui) violationRow(visible []Violation, index, width int) string {
+	if index >= len(visible) {
+		if index == 0 {
+			return fitText(tr("tui.no-violation"), width)
+		}
+		return strings.Repeat(" ", width)
+	}
+	v := visible[index]
+	text := fitText(fmt.Sprintf(" %-5s %4d:%-3d %-5s %s", strings.ToUpper(v.Severity.String()),
+		v.Line, v.Column, v.Rule, v.Message), width)
+	if index == t.item && t.focus == 1 {
+		return tuiReverse + text + tuiReset
+	}
+	switch v.Severity {
+	case SeverityMajor:
+		return ColorRed + text + tuiReset
+	case SeverityMinor:
+		return ColorYellow + text + tuiReset
+	}
+	return ColorBlue + text + tuiReset
+}
+
+// preview returns height rows of the selected file around the selected
+// violation, whose line is highlighted.
+func (t *tui) preview(visible []Violation, height, width int) []string {
+	rows := make([]string, max(height, 0))
+	for i := range rows {
+		rows[i] = strings.Repeat(" ", width)
+	}
+	if len(t.files) == 0 || height <= 0 {
+		return rows
+	}
+	lines := t.files[t.file].Lines
+	target := 0
+	if t.item < len(visible) {
+		target = visible[t.item].Line
+		rows[0] = fitText(" "+visible[t.item].Description, width)
+	}
+	first := clamp(target-(height-1)/2, 1, max(len(lines)-height+2, 1))
+	for row := 1; row < height; row++ {
+		number := first + row - 1
+		if number > len(lines) {
+			break
+		}
+		text := fitText(fmt.Sprintf("%5d  %s", number, lines[number-1]), width)
+		if number == target {
+			text = tuiReverse + text + tuiReset
+		}
+		rows[row] = text
+	}
+	return rows
+}
+
+// fitText expands tabs and cuts or pads text to exactly width runes.
+func fitText(text string, width int) string {
+	text = strings.ReplaceAll(strings.TrimRight(text, "\r"), "\t", "    ")
+	if count := utf8.RuneCountInString(text); count <= width {
+		return text + strings.Repeat(" ", width-count)
+	}
+	runes := []rune(text)
+	return string(runes[:width])
+}
+
+// decodeKeys splits terminal input into keys, decoding escape sequences.
+func decodeKeys(input []byte) []int {
+	sequences := map[string]int{
+		"\033[A": keyUp, "\033[B": keyDown, "\033[C": keyRight, "\033[D": keyLeft,
+		"\033OA": keyUp, "\033OB": keyDown, "\033OC": keyRight, "\033OD": keyLeft,
+		"\033[5~": keyPageUp, "\033[6~": keyPageDown,
+	}
+	var keys []int
+	for i := 0; i < len(input); {
+		if input[i] == '\033' {
+			matched := false
+			for seq, key := range sequences {
+				if strings.HasPrefix(string(input[i:]), seq) {
+					keys = append(keys, key)
+					i += len(seq)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				keys = append(keys, keyEscape)
+				i++
+			}
+			continue
+		}
+		switch input[i] {
+		case '\r', '\n':
+			keys = append(keys, keyEnter)
+		case 127, 8:
+			keys = append(keys, keyBackspace)
+		default:
+			keys = append(keys, int(input[i]))
+		}
+		i++
+	}
+	return keys
+}
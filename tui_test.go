@@ -0,0 +1,156 @@
+// tui_test.go
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func tuiReport() *Report {
+	return &Report{
+		Scoring: DefaultScoring,
+		Files: []FileResult{
+			{Filename: "b.c", Score: 80, Violations: []Violation{
+				{Rule: "C-L2", Severity: SeverityMinor, Line: 9},
+				{Rule: "C-F3", Severity: SeverityMajor, Line: 4},
+				{Rule: "C-F10", Severity: SeverityInfo, Line: 2},
+				{Rule: "C-G4", Severity: SeverityMajor, Line: 4, Column: 3},
+			}},
+			{Filename: "a.c", Score: 100},
+			{Filename: "c.c", Score: 50, Violations: []Violation{
+				{Rule: "C-O1", Severity: SeverityMajor, Line: 1},
+			}},
+		},
+	}
+}
+
+func violationRules(violations []Violation) []string {
+	var codes []string
+	for _, v := range violations {
+		codes = append(codes, v.Rule)
+	}
+	return codes
+}
+
+func filenames(files []FileResult) []string {
+	var names []string
+	for _, file := range files {
+		names = append(names, file.Filename)
+	}
+	return names
+}
+
+func TestTUIOrder(t *testing.T) {
+	ui := &tui{allLevel: true}
+	ui.setReport(tuiReport())
+	if got, want := filenames(ui.files), []string{"c.c", "b.c", "a.c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("files = %v, want the worst first %v", got, want)
+	}
+	if got, want := violationRules(ui.visible()), []string{"C-O1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("violations = %v, want %v", got, want)
+	}
+
+	// The selection follows the file when the order changes
+	ui.handle('j', "")
+	ui.handle('o', "")
+	if got, want := filenames(ui.files), []string{"a.c", "b.c", "c.c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("files by name = %v, want %v", got, want)
+	}
+	if ui.files[ui.file].Filename != "b.c" {
+		t.Errorf("selected %s after sorting by name, want b.c", ui.files[ui.file].Filename)
+	}
+	if got, want := violationRules(ui.visible()), []string{"C-F10", "C-F3", "C-G4", "C-L2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("violations = %v, want them in line order %v", got, want)
+	}
+}
+
+func TestTUIKeys(t *testing.T) {
+	ui := &tui{allLevel: true, height: 24}
+	ui.setReport(tuiReport())
+	ui.handle(keyDown, "")
+	ui.handle(keyDown, "")
+	ui.handle(keyDown, "")
+	if ui.file != 2 {
+		t.Errorf("file = %d after moving past the end, want 2", ui.file)
+	}
+	ui.handle(keyUp, "")
+	ui.handle(keyRight, "")
+	if ui.focus != 1 {
+		t.Errorf("focus = %d after right, want the violations", ui.focus)
+	}
+	ui.handle(keyPageDown, "")
+	if ui.file != 1 || ui.item != 3 {
+		t.Errorf("file %d, item %d after page down, want 1, 3", ui.file, ui.item)
+	}
+	ui.handle('\t', "")
+	if ui.focus != 0 {
+		t.Errorf("focus = %d after tab, want the files", ui.focus)
+	}
+	ui.handle('k', "")
+	if ui.file != 0 || ui.item != 0 {
+		t.Errorf("file %d, item %d after moving to another file, want 0, 0", ui.file, ui.item)
+	}
+	for _, key := range []int{'q', 3} {
+		if ui.handle(key, "") {
+			t.Errorf("key %d does not quit", key)
+		}
+	}
+	if !ui.handle('x', "") {
+		t.Error("an unbound key quits")
+	}
+}
+
+func TestTUIFilters(t *testing.T) {
+	ui := &tui{allLevel: true}
+	ui.setReport(tuiReport())
+	ui.handle('j', "")
+
+	// Severity cycles through all, major, minor and info
+	for _, want := range [][]string{
+		{"C-F3", "C-G4"},
+		{"C-L2"},
+		{"C-F10"},
+		{"C-F10", "C-F3", "C-G4", "C-L2"},
+	} {
+		ui.handle('s', "")
+		if got := violationRules(ui.visible()); !reflect.DeepEqual(got, want) {
+			t.Errorf("severity filter: got %v, want %v", got, want)
+		}
+	}
+
+	// The rule prefix is typed in lower case, edited, then applied
+	for _, key := range decodeKeys([]byte("/c-fx\x7f\r")) {
+		ui.handle(key, "")
+	}
+	if ui.rule != "C-F" || ui.input != nil {
+		t.Errorf("rule filter %q, input %v, want C-F applied", ui.rule, ui.input)
+	}
+	if got, want := violationRules(ui.visible()), []string{"C-F10", "C-F3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rule filter: got %v, want %v", got, want)
+	}
+	ui.handle('s', "")
+	if got, want := violationRules(ui.visible()), []string{"C-F3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rule and severity filters: got %v, want %v", got, want)
+	}
+
+	// Escape leaves the filter as it was
+	for _, key := range decodeKeys([]byte("rC-L\x1b")) {
+		ui.handle(key, "")
+	}
+	if ui.rule != "C-F" || ui.input != nil {
+		t.Errorf("rule filter %q after escape, want C-F", ui.rule)
+	}
+
+	ui.handle('c', "")
+	if got := ui.visible(); len(got) != 4 {
+		t.Errorf("%d violations after clearing the filters, want 4", len(got))
+	}
+}
+
+func TestDecodeKeys(t *testing.T) {
+	got := decodeKeys([]byte("\033[A\033OB\033[5~j\r\x7f\033"))
+	want := []int{keyUp, keyDown, keyPageUp, 'j', keyEnter, keyBackspace, keyEscape}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("decodeKeys = %v, want %v", got, want)
+	}
+}
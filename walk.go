@@ -0,0 +1,107 @@
+// walk.go
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// IgnoreFile lists, with the .gitignore syntax, the files epicstyle skips
+const IgnoreFile = ".epicstyleignore"
+
+// WalkOptions selects the sources analyzed in a directory. Patterns use
+// the .gitignore syntax relative to the analyzed directory: a pattern
+// without '/' matches a name at any depth, '**' any number of directories.
+type WalkOptions struct {
+	Include []string // when set, only the files matching one are analyzed
+	Exclude []string // files and directories to skip
+	Hidden  bool     // descend into directories whose name starts with '.', except .git
+}
+
+// collectSources returns the C sources below root, honoring the options
+// and the .gitignore and .epicstyleignore files found on the way. Entries
+// that cannot be read are passed to onError and skipped.
+func collectSources(root string, options WalkOptions, onError func(string, error)) ([]string, error) {
+	var files []string
+	ignored := &ignoreMatcher{}
+	excluded := &ignoreMatcher{}
+	for _, pattern := range options.Exclude {
+		excluded.AddPattern(pattern, "")
+	}
+	included := &ignoreMatcher{}
+	for _, pattern := range options.Include {
+		included.AddPattern(pattern, "")
+	}
+
+	err := filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			// Unreadable entries are reported, the rest of the tree is still analyzed
+			onError(p, err)
+			if info != nil && info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel := relSlash(root, p)
+		if info.IsDir() {
+			if rel != "" {
+				if info.Name() == ".git" || !options.Hidden && strings.HasPrefix(info.Name(), ".") {
+					return filepath.SkipDir
+				}
+				if ignored.Match(rel, true) || excluded.Match(rel, true) {
+					return filepath.SkipDir
+				}
+			}
+			for _, name := range []string{".gitignore", IgnoreFile} {
+				if err := ignored.AddFile(filepath.Join(p, name), rel); err != nil {
+					onError(filepath.Join(p, name), err)
+				}
+			}
+			return nil
+		}
+
+		if !isSourceFile(p) {
+			return nil
+		}
+		if ignored.Match(rel, false) || excluded.Match(rel, false) {
+			return nil
+		}
+		if len(options.Include) > 0 && !included.Match(rel, false) {
+			return nil
+		}
+		files = append(files, p)
+		return nil
+	})
+	return files, err
+}
+
+// coveredDirs returns the indexes of the paths naming a directory that
+// another path already walks: a repeated directory or a subdirectory.
+func coveredDirs(paths []string) map[int]bool {
+	dirs := make([]string, len(paths))
+	for i, path := range paths {
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			dirs[i], _ = filepath.Abs(path)
+		}
+	}
+
+	covered := make(map[int]bool)
+	for i, dir := range dirs {
+		for j, other := range dirs {
+			if i == j || dir == "" || other == "" {
+				continue
+			}
+			if dir == other && j < i || dir != other && isWithin(dir, other) {
+				covered[i] = true
+			}
+		}
+	}
+	return covered
+}
+
+// isWithin reports whether path lies below dir, both absolute.
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,141 @@
+// walk_test.go
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCollectSources(t *testing.T) {
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		"main.c":                 "",
+		"main.h":                 "",
+		"notes.txt":              "",
+		"src/util.c":             "",
+		"src/util_test.c":        "",
+		"src/gen/parser.c":       "",
+		"tests/test_main.c":      "",
+		".hidden/secret.c":       "",
+		"src/.cache/cached.c":    "",
+		".git/hooks/hook.c":      "",
+		"lib/vendor.c":           "",
+		"lib/.gitignore":         "vendor.c\n",
+		".epicstyleignore":       "gen/\n",
+		"bonus/bonus.c":          "",
+		"bonus/.epicstyleignore": "",
+	} {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		options WalkOptions
+		want    []string
+	}{
+		{"default", WalkOptions{},
+			[]string{"bonus/bonus.c", "main.c", "main.h", "src/util.c", "src/util_test.c", "tests/test_main.c"}},
+		{"hidden directories", WalkOptions{Hidden: true},
+			[]string{".hidden/secret.c", "bonus/bonus.c", "main.c", "main.h", "src/.cache/cached.c", "src/util.c", "src/util_test.c", "tests/test_main.c"}},
+		{"include", WalkOptions{Include: []string{"*.c"}},
+			[]string{"bonus/bonus.c", "main.c", "src/util.c", "src/util_test.c", "tests/test_main.c"}},
+		{"include anchored", WalkOptions{Include: []string{"src/**"}},
+			[]string{"src/util.c", "src/util_test.c"}},
+		{"exclude", WalkOptions{Exclude: []string{"*_test.c", "tests/"}},
+			[]string{"bonus/bonus.c", "main.c", "main.h", "src/util.c"}},
+		{"exclude wins over include", WalkOptions{Include: []string{"src/*.c"}, Exclude: []string{"util_test.c"}},
+			[]string{"src/util.c"}},
+		{"excluded directory wins over include", WalkOptions{Include: []string{"tests/*.c"}, Exclude: []string{"tests"}},
+			nil},
+		{"ignore files win over include", WalkOptions{Include: []string{"lib/*.c", "src/gen/*.c"}},
+			nil},
+		{"include does not reach hidden directories", WalkOptions{Include: []string{".hidden/*.c"}},
+			nil},
+		{"include in hidden directories", WalkOptions{Include: []string{".hidden/*.c"}, Hidden: true},
+			[]string{".hidden/secret.c"}},
+		{".git is never walked", WalkOptions{Include: []string{"**/*.c"}, Hidden: true, Exclude: []string{"src"}},
+			[]string{".hidden/secret.c", "bonus/bonus.c", "main.c", "tests/test_main.c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := collectSources(root, tt.options, func(p string, err error) {
+				t.Errorf("unexpected error on %s: %v", p, err)
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, file := range files {
+				got = append(got, relSlash(root, file))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoveredDirs(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"src/sub", "srcs"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, "src", "main.c"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	path := func(name string) string { return filepath.Join(root, name) }
+
+	tests := []struct {
+		name  string
+		paths []string
+		want  map[int]bool
+	}{
+		{"distinct", []string{path("src"), path("srcs")}, map[int]bool{}},
+		{"repeated", []string{path("src"), path("src") + "/", path("src/../src")}, map[int]bool{1: true, 2: true}},
+		{"subdirectory first", []string{path("src/sub"), path("src")}, map[int]bool{0: true}},
+		{"subdirectory last", []string{path("src"), path("src/sub")}, map[int]bool{1: true}},
+		{"file inside", []string{path("src"), path("src/main.c")}, map[int]bool{}},
+		{"missing", []string{path("src"), path("src/missing")}, map[int]bool{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := coveredDirs(tt.paths); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("coveredDirs(%v) = %v, want %v", tt.paths, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzePathsOverlappingDirs(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"main.o", "sub/util.o"} {
+		if err := os.WriteFile(filepath.Join(root, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config := DefaultConfig()
+	config.Enable("C-O4")
+	report := NewAnalyzer(2, config).AnalyzePaths([]string{root, filepath.Join(root, "sub"), root})
+	var got []string
+	for _, file := range report.Files {
+		got = append(got, file.Filename)
+	}
+	want := []string{filepath.Join(root, "main.o"), filepath.Join(root, "sub", "util.o")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got files %v, want %v", got, want)
+	}
+}